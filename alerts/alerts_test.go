@@ -0,0 +1,156 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/esenmx/gocko"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func prices(id, vsc string, price float64, change24h *float64) gocko.SimplePrices {
+	return gocko.SimplePrices{Prices: map[string]gocko.SimplePrice{
+		id: {CurrencyPrice: map[string]gocko.Price{vsc: {Price: price, Change24h: change24h}}},
+	}}
+}
+
+func TestEngineCrossing(t *testing.T) {
+	var alerts []Alert
+	e, err := NewEngine(NotifierFunc(func(a Alert) error { alerts = append(alerts, a); return nil }), Rule{
+		Id: "dot-13", CoinId: "polkadot", VsCurrency: "usd", Kind: PriceCrossesAbove, Threshold: 13, Hysteresis: 0.5,
+	})
+	require.NoError(t, err)
+	for _, p := range []float64{13.5, 12, 13.1, 13.4, 12.8, 13.2, 12.4, 13} {
+		require.NoError(t, e.EvaluatePrices(prices("polkadot", "usd", p, nil)))
+	}
+	// 13.5 is the baseline, 13.2 is within the hysteresis of 12.8
+	require.Equal(t, 2, len(alerts))
+	require.Equal(t, 13.1, alerts[0].Value)
+	require.Equal(t, 13.0, alerts[1].Value)
+	require.Equal(t, "dot-13", alerts[1].Rule.Id)
+}
+
+func TestEngineCooldown(t *testing.T) {
+	now := time.Date(2022, 3, 29, 0, 0, 0, 0, time.UTC)
+	var alerts []Alert
+	e, err := NewEngine(NotifierFunc(func(a Alert) error { alerts = append(alerts, a); return nil }), Rule{
+		Id: "sol-dump", CoinId: "solana", VsCurrency: "usd", Kind: Change24hBelow, Threshold: -10, Cooldown: Duration(time.Hour),
+	})
+	require.NoError(t, err)
+	e.now = func() time.Time { return now }
+	for _, c := range []float64{-11, -9, -12, -8} {
+		c := c
+		require.NoError(t, e.EvaluatePrices(prices("solana", "usd", 25, &c)))
+		now = now.Add(10 * time.Minute)
+	}
+	require.Equal(t, 1, len(alerts))
+	now = now.Add(time.Hour)
+	c := -10.0
+	require.NoError(t, e.EvaluatePrices(prices("solana", "usd", 25, &c)))
+	require.Equal(t, 2, len(alerts))
+	require.NoError(t, e.EvaluatePrices(prices("solana", "usd", 25, nil)))
+	require.NoError(t, e.EvaluatePrices(prices("solana", "eur", 25, &c)))
+	require.Equal(t, 2, len(alerts))
+}
+
+func TestEngineMarkets(t *testing.T) {
+	now := time.Date(2022, 3, 29, 0, 0, 0, 0, time.UTC)
+	var alerts []Alert
+	e, err := NewEngine(NotifierFunc(func(a Alert) error { alerts = append(alerts, a); return nil }),
+		Rule{Id: "eth-volume", CoinId: "ethereum", VsCurrency: "usd", Kind: VolumeSpike, Threshold: 2},
+		Rule{Id: "eth-deviation", CoinId: "ethereum", VsCurrency: "usd", Kind: SparklineDeviation, Threshold: 10},
+	)
+	require.NoError(t, err)
+	e.now = func() time.Time { return now }
+	market := func(price, volume float64) []gocko.Market {
+		m := gocko.Market{CurrentPrice: price, TotalVolume: volume, SparklineIn7D: &gocko.SparklineIn7D{Price: []float64{1900, 2000, 2100}}}
+		m.Id = "ethereum"
+		return []gocko.Market{m}
+	}
+	require.NoError(t, e.EvaluateMarkets("usd", market(2000, 100)))
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, e.EvaluateMarkets("usd", market(2100, 150)))
+	require.Empty(t, alerts)
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, e.EvaluateMarkets("usd", market(2250, 500)))
+	require.Equal(t, 2, len(alerts))
+	require.Equal(t, "eth-deviation", alerts[0].Rule.Id)
+	require.InDelta(t, 12.5, alerts[0].Value, 1e-9)
+	require.Equal(t, "eth-volume", alerts[1].Rule.Id)
+	require.Equal(t, 4.0, alerts[1].Value)
+	// a fresh engine waits for a day of observations
+	alerts = nil
+	e, err = NewEngine(NotifierFunc(func(a Alert) error { alerts = append(alerts, a); return nil }),
+		Rule{Id: "eth-volume", CoinId: "ethereum", VsCurrency: "usd", Kind: VolumeSpike, Threshold: 2})
+	require.NoError(t, err)
+	e.now = func() time.Time { return now }
+	require.NoError(t, e.EvaluateMarkets("usd", market(2000, 100)))
+	now = now.Add(time.Hour)
+	require.NoError(t, e.EvaluateMarkets("usd", market(2000, 500)))
+	require.Empty(t, alerts)
+	now = now.Add(23 * time.Hour)
+	require.NoError(t, e.EvaluateMarkets("usd", market(2000, 1000)))
+	require.Equal(t, 1, len(alerts))
+	require.Equal(t, 1000.0/300, alerts[0].Value)
+}
+
+func TestEngineWatch(t *testing.T) {
+	got := make(chan Alert, 1)
+	e, err := NewEngine(NotifierFunc(func(a Alert) error { got <- a; return nil }),
+		Rule{Id: "dot", CoinId: "polkadot", VsCurrency: "usd", Kind: PriceCrossesBelow, Threshold: 10})
+	require.NoError(t, err)
+	updates := make(chan gocko.PriceUpdate, 2)
+	updates <- gocko.PriceUpdate{Id: "polkadot", VsCurrency: "usd", Price: gocko.Price{Price: 11}}
+	updates <- gocko.PriceUpdate{Id: "polkadot", VsCurrency: "usd", Price: gocko.Price{Price: 9}}
+	close(updates)
+	require.NoError(t, e.Watch(context.Background(), updates, nil))
+	require.Equal(t, 9.0, (<-got).Value)
+}
+
+func TestPersistence(t *testing.T) {
+	rules := []Rule{
+		{Id: "a", CoinId: "polkadot", VsCurrency: "usd", Kind: PriceCrossesAbove, Threshold: 13, Cooldown: Duration(15 * time.Minute)},
+		{Id: "b", CoinId: "solana", VsCurrency: "eur", Kind: Change24hBelow, Threshold: -10, Hysteresis: 1},
+	}
+	name := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, SaveFile(name, rules))
+	loaded, err := LoadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, rules, loaded)
+	e, err := NewEngine(NewStdoutNotifier(), loaded...)
+	require.NoError(t, err)
+	require.Equal(t, rules, e.Rules())
+
+	var buf bytes.Buffer
+	require.NoError(t, Save(&buf, rules[:1]))
+	require.Contains(t, buf.String(), `"cooldown": "15m0s"`)
+	_, err = Load(bytes.NewBufferString(`[{"id":"x","coin_id":"polkadot","vs_currency":"usd","kind":"moon"}]`))
+	require.ErrorIs(t, err, InvalidRuleError)
+}
+
+func TestNotifiers(t *testing.T) {
+	a := Alert{Rule: Rule{Id: "a", CoinId: "polkadot", VsCurrency: "usd", Kind: PriceCrossesAbove, Threshold: 13},
+		Value: 13.1, Time: time.Date(2022, 3, 29, 0, 0, 0, 0, time.UTC)}
+	var buf bytes.Buffer
+	require.NoError(t, WriterNotifier{W: &buf}.Notify(a))
+	require.Equal(t, "2022-03-29T00:00:00Z polkadot/usd price_crosses_above 13: 13.1\n", buf.String())
+
+	var posted Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		if posted.Value > 100 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	require.NoError(t, WebhookNotifier{URL: srv.URL}.Notify(a))
+	require.Equal(t, a, posted)
+	a.Value = 200
+	require.Error(t, WebhookNotifier{URL: srv.URL}.Notify(a))
+}
@@ -0,0 +1,263 @@
+package alerts
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/esenmx/gocko"
+)
+
+const (
+	volumeWindow = 7 * 24 * time.Hour
+	volumeWarmup = 24 * time.Hour // span of observations needed before VolumeSpike rules are evaluated
+)
+
+// Engine keeps the rules and their trigger state, it's safe for concurrent use.
+type Engine struct {
+	notifier Notifier
+	now      func() time.Time
+
+	mu      sync.Mutex
+	rules   map[string]Rule
+	states  map[string]*state
+	volumes map[string][]sample // coin id + "/" + vs currency
+}
+
+type state struct {
+	observed bool
+	armed    bool
+	firedAt  time.Time
+}
+
+type sample struct {
+	time   time.Time
+	volume float64
+}
+
+type observation struct {
+	coinId     string
+	vsCurrency string
+	price      float64
+	change24h  *float64
+	volume     *float64
+	sparkline  []float64
+}
+
+func NewEngine(n Notifier, rules ...Rule) (*Engine, error) {
+	e := &Engine{
+		notifier: n,
+		now:      time.Now,
+		rules:    map[string]Rule{},
+		states:   map[string]*state{},
+		volumes:  map[string][]sample{},
+	}
+	for _, r := range rules {
+		if err := e.Add(r); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Add inserts or replaces the rule with the same Id, replacing resets its state.
+func (e *Engine) Add(r Rule) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules[r.Id] = r
+	delete(e.states, r.Id)
+	return nil
+}
+
+func (e *Engine) Remove(id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.rules, id)
+	delete(e.states, id)
+}
+
+// Rules returns the rules ordered by Id, ready for Save.
+func (e *Engine) Rules() []Rule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rules := make([]Rule, 0, len(e.rules))
+	for _, r := range e.rules {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Id < rules[j].Id })
+	return rules
+}
+
+// EvaluatePrices checks the rules against a SimplePrice snapshot, request Include24hrChange for the change rules.
+// Every triggered alert is notified, the first notification error is returned.
+func (e *Engine) EvaluatePrices(sps gocko.SimplePrices) error {
+	var err error
+	for id, sp := range sps.Prices {
+		for vsc, p := range sp.CurrencyPrice {
+			if nerr := e.evaluate(observation{coinId: id, vsCurrency: vsc, price: p.Price, change24h: p.Change24h}); err == nil {
+				err = nerr
+			}
+		}
+	}
+	return err
+}
+
+// EvaluateMarkets checks the rules against a CoinsMarkets snapshot in vsCurrency,
+// request Sparkline for the sparkline rules.
+func (e *Engine) EvaluateMarkets(vsCurrency string, ms []gocko.Market) error {
+	var err error
+	for _, m := range ms {
+		o := observation{
+			coinId:     m.Id,
+			vsCurrency: vsCurrency,
+			price:      m.CurrentPrice,
+			change24h:  &m.PriceChangePercentage24H,
+			volume:     &m.TotalVolume,
+		}
+		if m.PriceChangePercentage24HInCurrency != nil {
+			o.change24h = m.PriceChangePercentage24HInCurrency
+		}
+		if m.SparklineIn7D != nil {
+			o.sparkline = m.SparklineIn7D.Price
+		}
+		if nerr := e.evaluate(o); err == nil {
+			err = nerr
+		}
+	}
+	return err
+}
+
+// Watch evaluates the updates of a gocko.Watcher until the channel is closed or ctx is done,
+// notification errors are passed to onError when it's not nil.
+func (e *Engine) Watch(ctx context.Context, updates <-chan gocko.PriceUpdate, onError func(error)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case u, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			err := e.evaluate(observation{coinId: u.Id, vsCurrency: u.VsCurrency, price: u.Price.Price, change24h: u.Price.Change24h})
+			if err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (e *Engine) evaluate(o observation) error {
+	e.mu.Lock()
+	now := e.now()
+	key := o.coinId + "/" + o.vsCurrency
+	// the average volume comes from the engine's own observations of the last 7 days, CoinGecko's sparkline
+	// only carries prices, it stays 0 until they span volumeWarmup so a restart can't compare against one sample
+	avgVolume := 0.0
+	if o.volume != nil {
+		samples := e.volumes[key][:0]
+		for _, s := range e.volumes[key] {
+			if now.Sub(s.time) <= volumeWindow {
+				samples = append(samples, s)
+				avgVolume += s.volume
+			}
+		}
+		if len(samples) > 0 && now.Sub(samples[0].time) >= volumeWarmup {
+			avgVolume /= float64(len(samples))
+		} else {
+			avgVolume = 0
+		}
+		e.volumes[key] = append(samples, sample{time: now, volume: *o.volume})
+	}
+	var alerts []Alert
+	for id, r := range e.rules {
+		if r.CoinId != o.coinId || r.VsCurrency != o.vsCurrency {
+			continue
+		}
+		v, ok := value(r.Kind, o, avgVolume)
+		if !ok {
+			continue
+		}
+		s := e.states[id]
+		if s == nil {
+			s = &state{armed: true}
+			e.states[id] = s
+		}
+		if e.step(r, s, v, now) {
+			alerts = append(alerts, Alert{Rule: r, Value: v, Time: now})
+		}
+	}
+	e.mu.Unlock()
+
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Rule.Id < alerts[j].Rule.Id })
+	var err error
+	for _, a := range alerts {
+		if nerr := e.notifier.Notify(a); err == nil {
+			err = nerr
+		}
+	}
+	return err
+}
+
+// step advances the rule state with v and reports whether the rule fires.
+func (e *Engine) step(r Rule, s *state, v float64, now time.Time) bool {
+	hit := v >= r.Threshold
+	rearm := v < r.Threshold-r.Hysteresis
+	if r.Kind.below() {
+		hit = v <= r.Threshold
+		rearm = v > r.Threshold+r.Hysteresis
+	}
+	if !s.observed {
+		s.observed = true
+		// a crossing needs to be seen on the other side of the threshold first
+		if r.Kind.crossing() {
+			s.armed = !hit
+			return false
+		}
+	}
+	if !s.armed {
+		s.armed = rearm
+		return false
+	}
+	if !hit || (!s.firedAt.IsZero() && now.Sub(s.firedAt) < time.Duration(r.Cooldown)) {
+		return false
+	}
+	s.armed = false
+	s.firedAt = now
+	return true
+}
+
+func value(k Kind, o observation, avgVolume float64) (float64, bool) {
+	switch k {
+	case PriceCrossesAbove, PriceCrossesBelow:
+		return o.price, true
+	case Change24hAbove, Change24hBelow:
+		if o.change24h == nil {
+			return 0, false
+		}
+		return *o.change24h, true
+	case VolumeSpike:
+		if o.volume == nil || avgVolume <= 0 {
+			return 0, false
+		}
+		return *o.volume / avgVolume, true
+	case SparklineDeviation:
+		if len(o.sparkline) == 0 {
+			return 0, false
+		}
+		sum := 0.0
+		for _, p := range o.sparkline {
+			sum += p
+		}
+		avg := sum / float64(len(o.sparkline))
+		if avg == 0 {
+			return 0, false
+		}
+		return math.Abs(o.price/avg-1) * 100, true
+	}
+	return 0, false
+}
@@ -0,0 +1,68 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+type Alert struct {
+	Rule  Rule      `json:"rule"`
+	Value float64   `json:"value"`
+	Time  time.Time `json:"time"`
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("%s %s/%s %s %g: %g", a.Time.Format(time.RFC3339), a.Rule.CoinId, a.Rule.VsCurrency,
+		a.Rule.Kind, a.Rule.Threshold, a.Value)
+}
+
+type Notifier interface {
+	Notify(Alert) error
+}
+
+type NotifierFunc func(Alert) error
+
+func (f NotifierFunc) Notify(a Alert) error { return f(a) }
+
+// WriterNotifier writes one line per alert.
+type WriterNotifier struct {
+	W io.Writer
+}
+
+func NewStdoutNotifier() WriterNotifier { return WriterNotifier{W: os.Stdout} }
+
+func (n WriterNotifier) Notify(a Alert) error {
+	_, err := fmt.Fprintln(n.W, a)
+	return err
+}
+
+// WebhookNotifier POSTs each alert as JSON to URL, any non 2xx status is an error.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client // defaults to http.DefaultClient
+}
+
+func (n WebhookNotifier) Notify(a Alert) error {
+	bs, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	hc := n.Client
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Post(n.URL, "application/json", bytes.NewReader(bs))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: %s", n.URL, res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package alerts evaluates price rules against gocko snapshots and hands the triggered alerts to a Notifier.
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+var InvalidRuleError = errors.New("invalid rule")
+
+// Kind is what a rule watches. VolumeSpike compares against the average of the 24h volumes the engine itself
+// observed in EvaluateMarkets over the last 7 days, kept in memory: after a start it stays silent until those
+// observations span a day, and the average covers less than 7 days during the first week.
+type Kind string
+
+const (
+	PriceCrossesAbove  Kind = "price_crosses_above" // price moves from below Threshold to Threshold or above
+	PriceCrossesBelow  Kind = "price_crosses_below" // price moves from above Threshold to Threshold or below
+	Change24hAbove     Kind = "change_24h_above"    // 24h change percentage is Threshold or above
+	Change24hBelow     Kind = "change_24h_below"    // 24h change percentage is Threshold or below, eg. -10
+	VolumeSpike        Kind = "volume_spike"        // 24h volume is Threshold times its observed average, markets only
+	SparklineDeviation Kind = "sparkline_deviation" // price deviates Threshold percent from the 7d sparkline average, markets only
+)
+
+func (k Kind) crossing() bool { return k == PriceCrossesAbove || k == PriceCrossesBelow }
+
+func (k Kind) below() bool { return k == PriceCrossesBelow || k == Change24hBelow }
+
+type Rule struct {
+	Id         string  `json:"id"`
+	CoinId     string  `json:"coin_id"`
+	VsCurrency string  `json:"vs_currency"`
+	Kind       Kind    `json:"kind"`
+	Threshold  float64 `json:"threshold"`
+	// Hysteresis is the margin the value has to move back past Threshold before the rule fires again.
+	Hysteresis float64 `json:"hysteresis,omitempty"`
+	// Cooldown is the minimum time between two alerts of the rule.
+	Cooldown Duration `json:"cooldown,omitempty"`
+}
+
+func (r Rule) validate() error {
+	if len(r.Id) == 0 || len(r.CoinId) == 0 || len(r.VsCurrency) == 0 || r.Hysteresis < 0 || r.Cooldown < 0 {
+		return InvalidRuleError
+	}
+	switch r.Kind {
+	case PriceCrossesAbove, PriceCrossesBelow, Change24hAbove, Change24hBelow, SparklineDeviation:
+	case VolumeSpike:
+		if r.Threshold <= 0 {
+			return InvalidRuleError
+		}
+	default:
+		return InvalidRuleError
+	}
+	return nil
+}
+
+// Duration is a time.Duration persisted in its string form, eg. "15m".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(bs []byte) error {
+	var s string
+	if err := json.Unmarshal(bs, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	*d = Duration(v)
+	return err
+}
+
+func Save(w io.Writer, rules []Rule) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rules)
+}
+
+func Load(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+func SaveFile(name string, rules []Rule) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err = Save(f, rules); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func LoadFile(name string) ([]Rule, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
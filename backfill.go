@@ -0,0 +1,135 @@
+package gocko
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type Granularity int
+
+// CoinGecko returns 5 minute points only for ranges within the last day, older ranges of up to 90 days are hourly.
+const (
+	GranularityHourly   Granularity = iota // hourly points
+	GranularityMinutely                    // 5 minute points for the part of the range within the last 24 hours, hourly before
+)
+
+// chunks splits [from, to] into ranges small enough to receive g, only the last day before now is worth
+// requesting at 5 minute granularity.
+func (g Granularity) chunks(from, to, now time.Time) []CoinsChartsRangeParams {
+	hourly := 90 * 24 * time.Hour
+	if cut := now.Add(-24 * time.Hour); g == GranularityMinutely && to.After(cut) {
+		if from.After(cut) {
+			cut = from
+		}
+		return append(splitRange(from, cut, hourly), splitRange(cut, to, 24*time.Hour)...)
+	}
+	return splitRange(from, to, hourly)
+}
+
+type CoinsChartsBackfillParams struct {
+	Id          string    // required
+	VsCurrency  string    // required
+	From        time.Time // required
+	To          time.Time // required
+	Granularity Granularity
+	Concurrency int // parallel requests, defaults to 4, the client rate limit still applies
+}
+
+// CoinsMarketChartsBackfill fetches [From, To] through coins/{id}/market_chart/range in chunks small enough
+// to keep the requested granularity and stitches them into one Charts ordered by timestamp.
+func (c *Client) CoinsMarketChartsBackfill(p CoinsChartsBackfillParams) (Charts, error) {
+	chunks := p.Granularity.chunks(p.From, p.To, time.Now())
+	if len(chunks) == 0 {
+		// let the range params report what's wrong
+		_, err := CoinsChartsRangeParams{Id: p.Id, VsCurrency: p.VsCurrency, From: p.From, To: p.To}.Query()
+		return Charts{}, err
+	}
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	results := make([]Charts, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk CoinsChartsRangeParams) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			chunk.Id, chunk.VsCurrency = p.Id, p.VsCurrency
+			results[i], errs[i] = c.CoinsMarketChartsRange(chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return Charts{}, err
+		}
+	}
+	var ccs Charts
+	for _, r := range results {
+		ccs.Prices = append(ccs.Prices, r.Prices...)
+		ccs.MarketCaps = append(ccs.MarketCaps, r.MarketCaps...)
+		ccs.TotalVolumes = append(ccs.TotalVolumes, r.TotalVolumes...)
+	}
+	ccs.Prices = dedupe(ccs.Prices)
+	ccs.MarketCaps = dedupe(ccs.MarketCaps)
+	ccs.TotalVolumes = dedupe(ccs.TotalVolumes)
+	if c.decimals {
+		ccs.Exact = &ExactCharts{}
+		for _, r := range results {
+			if r.Exact != nil {
+				ccs.Exact.Prices = append(ccs.Exact.Prices, r.Exact.Prices...)
+				ccs.Exact.MarketCaps = append(ccs.Exact.MarketCaps, r.Exact.MarketCaps...)
+				ccs.Exact.TotalVolumes = append(ccs.Exact.TotalVolumes, r.Exact.TotalVolumes...)
+			}
+		}
+		ccs.Exact.Prices = dedupeExact(ccs.Exact.Prices)
+		ccs.Exact.MarketCaps = dedupeExact(ccs.Exact.MarketCaps)
+		ccs.Exact.TotalVolumes = dedupeExact(ccs.Exact.TotalVolumes)
+	}
+	return ccs, nil
+}
+
+func splitRange(from, to time.Time, size time.Duration) []CoinsChartsRangeParams {
+	var chunks []CoinsChartsRangeParams
+	if from.IsZero() || to.IsZero() {
+		return nil
+	}
+	for start := from; start.Before(to); start = start.Add(size) {
+		end := start.Add(size)
+		if end.After(to) {
+			end = to
+		}
+		chunks = append(chunks, CoinsChartsRangeParams{From: start, To: end})
+	}
+	return chunks
+}
+
+// dedupe sorts the series by timestamp and keeps the first point of each timestamp.
+func dedupe(series [][2]float64) [][2]float64 {
+	sort.SliceStable(series, func(i, j int) bool { return series[i][0] < series[j][0] })
+	out := series[:0]
+	for i, v := range series {
+		if i > 0 && v[0] == out[len(out)-1][0] {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func dedupeExact(series []DecimalPoint) []DecimalPoint {
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Time.Before(series[j].Time) })
+	out := series[:0]
+	for i, v := range series {
+		if i > 0 && v.Time.Equal(out[len(out)-1].Time) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
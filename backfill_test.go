@@ -0,0 +1,67 @@
+package gocko
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_CoinsMarketChartsBackfill(t *testing.T) {
+	var calls, unexpected int32
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		// the transport runs on the backfill goroutines, mismatches are asserted afterwards
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path != "/api/v3/coins/polkadot/market_chart/range" {
+			atomic.AddInt32(&unexpected, 1)
+		}
+		from, _ := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+		to, _ := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+		// hourly points, both ends inclusive so that neighbouring chunks overlap
+		var ccs Charts
+		for ts := from; ts <= to; ts += 3600 {
+			p := [2]float64{float64(ts * 1000), float64(ts)}
+			ccs.Prices = append(ccs.Prices, p)
+			ccs.MarketCaps = append(ccs.MarketCaps, p)
+			ccs.TotalVolumes = append(ccs.TotalVolumes, p)
+		}
+		return jsonResponse(ccs)
+	})}
+	c := NewClient(WithHttpClient(hc))
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	ccs, err := c.CoinsMarketChartsBackfill(CoinsChartsBackfillParams{Id: "polkadot", VsCurrency: "usd", From: from, To: to})
+	require.NoError(t, err)
+	require.Zero(t, atomic.LoadInt32(&unexpected))
+	require.Equal(t, int32(5), calls)
+	require.Equal(t, 365*24+1, len(ccs.Prices))
+	rows, err := ccs.Rows()
+	require.NoError(t, err)
+	for i := 1; i < len(rows); i++ {
+		require.Equal(t, time.Hour, rows[i].Time.Sub(rows[i-1].Time))
+	}
+
+	_, err = c.CoinsMarketChartsBackfill(CoinsChartsBackfillParams{Id: "polkadot", VsCurrency: "usd", From: to, To: from})
+	require.ErrorIs(t, err, InvalidParameterError)
+
+	// history gains nothing from minutely chunks
+	calls = 0
+	_, err = c.CoinsMarketChartsBackfill(CoinsChartsBackfillParams{Id: "polkadot", VsCurrency: "usd", From: from, To: to, Granularity: GranularityMinutely})
+	require.NoError(t, err)
+	require.Equal(t, int32(5), calls)
+}
+
+func TestGranularity_Chunks(t *testing.T) {
+	now := time.Date(2021, 7, 22, 12, 0, 0, 0, time.UTC)
+	chunks := GranularityMinutely.chunks(now.AddDate(0, 0, -10), now, now)
+	require.Len(t, chunks, 2)
+	require.Equal(t, now.AddDate(0, 0, -1), chunks[0].To)
+	require.Equal(t, CoinsChartsRangeParams{From: now.AddDate(0, 0, -1), To: now}, chunks[1])
+
+	chunks = GranularityMinutely.chunks(now.Add(-6*time.Hour), now, now)
+	require.Equal(t, []CoinsChartsRangeParams{{From: now.Add(-6 * time.Hour), To: now}}, chunks)
+
+	require.Len(t, GranularityHourly.chunks(now.AddDate(0, 0, -10), now, now), 1)
+}
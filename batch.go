@@ -0,0 +1,176 @@
+package gocko
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type SimplePriceBatchParams struct {
+	SimplePriceParams
+	MaxQueryLength int // encoded length of ids and vs_currencies per request, defaults to 2000
+	Concurrency    int // parallel requests, defaults to 4, the client rate limit still applies
+}
+
+type ChunkError struct {
+	Ids          []string // coin ids, or contract addresses for SimpleTokenPriceBatch
+	VsCurrencies []string
+	Err          error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk of %d ids and %d vs currencies: %s", len(e.Ids), len(e.VsCurrencies), e.Err)
+}
+
+func (e *ChunkError) Unwrap() error { return e.Err }
+
+// ChunkErrors lists the failed chunks of a batch, the prices of the other chunks are still returned.
+type ChunkErrors []*ChunkError
+
+func (es ChunkErrors) Error() string {
+	return fmt.Sprintf("%d chunks failed, first: %s", len(es), es[0])
+}
+
+func (es ChunkErrors) Is(target error) bool {
+	for _, e := range es {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// SimplePriceBatch splits Ids and VsCurrencies into chunks whose query stays under MaxQueryLength,
+// requests them concurrently and merges the results. Failed chunks are reported as ChunkErrors.
+func (c *Client) SimplePriceBatch(p SimplePriceBatchParams) (SimplePrices, error) {
+	sps := SimplePrices{vsCurrencies: p.VsCurrencies, Prices: map[string]SimplePrice{}}
+	if _, err := p.SimplePriceParams.Query(); err != nil {
+		return sps, err
+	}
+	chunks := priceChunks(p.Ids, p.VsCurrencies, p.MaxQueryLength)
+	err := fetchChunks(&sps, chunks, p.Concurrency, func(ch priceChunk) (SimplePrices, error) {
+		chunk := p.SimplePriceParams
+		chunk.Ids, chunk.VsCurrencies = ch.ids, ch.vsCurrencies
+		return c.SimplePrice(chunk)
+	})
+	return sps, err
+}
+
+type SimpleTokenPriceBatchParams struct {
+	SimpleTokenPriceParams
+	MaxQueryLength int // encoded length of contract_addresses and vs_currencies per request, defaults to 2000
+	Concurrency    int // parallel requests, defaults to 4, the client rate limit still applies
+}
+
+// SimpleTokenPriceBatch is SimplePriceBatch for the ContractAddresses of a platform, the Ids of the
+// ChunkErrors are contract addresses.
+func (c *Client) SimpleTokenPriceBatch(p SimpleTokenPriceBatchParams) (SimplePrices, error) {
+	sps := SimplePrices{vsCurrencies: p.VsCurrencies, Prices: map[string]SimplePrice{}}
+	if _, err := p.SimpleTokenPriceParams.Query(); err != nil {
+		return sps, err
+	}
+	chunks := priceChunks(p.ContractAddresses, p.VsCurrencies, p.MaxQueryLength)
+	err := fetchChunks(&sps, chunks, p.Concurrency, func(ch priceChunk) (SimplePrices, error) {
+		chunk := p.SimpleTokenPriceParams
+		chunk.ContractAddresses, chunk.VsCurrencies = ch.ids, ch.vsCurrencies
+		return c.SimpleTokenPrice(chunk)
+	})
+	return sps, err
+}
+
+type priceChunk struct {
+	ids, vsCurrencies []string
+}
+
+func priceChunks(ids, vsCurrencies []string, maxLen int) []priceChunk {
+	if maxLen <= 0 {
+		maxLen = 2000
+	}
+	var chunks []priceChunk
+	for _, vscs := range chunkJoined(vsCurrencies, maxLen/4) {
+		for _, ids := range chunkJoined(ids, maxLen-joinedLen(vscs)) {
+			chunks = append(chunks, priceChunk{ids: ids, vsCurrencies: vscs})
+		}
+	}
+	return chunks
+}
+
+// fetchChunks runs fetch for the chunks, concurrency at a time, and merges the results into sps.
+func fetchChunks(sps *SimplePrices, chunks []priceChunk, concurrency int, fetch func(priceChunk) (SimplePrices, error)) error {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	var mu sync.Mutex
+	var errs ChunkErrors
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(chunk priceChunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, err := fetch(chunk)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, &ChunkError{Ids: chunk.ids, VsCurrencies: chunk.vsCurrencies, Err: err})
+				return
+			}
+			sps.merge(res)
+		}(chunk)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (r *SimplePrices) merge(o SimplePrices) {
+	for id, sp := range o.Prices {
+		prev, ok := r.Prices[id]
+		if !ok {
+			r.Prices[id] = sp
+			continue
+		}
+		for vsc, price := range sp.CurrencyPrice {
+			prev.CurrencyPrice[vsc] = price
+		}
+		if prev.LastUpdatedAt == nil || (sp.LastUpdatedAt != nil && *sp.LastUpdatedAt > *prev.LastUpdatedAt) {
+			prev.LastUpdatedAt = sp.LastUpdatedAt
+		}
+		r.Prices[id] = prev
+	}
+}
+
+// chunkJoined splits values so that each chunk, comma joined and query escaped, fits in maxLen.
+// A single value longer than maxLen still gets its own chunk.
+func chunkJoined(values []string, maxLen int) [][]string {
+	var chunks [][]string
+	var chunk []string
+	length := 0
+	for _, v := range values {
+		l := len(url.QueryEscape(v))
+		if len(chunk) > 0 {
+			l += len(url.QueryEscape(","))
+		}
+		if len(chunk) > 0 && length+l > maxLen {
+			chunks = append(chunks, chunk)
+			chunk, length = nil, 0
+			l = len(url.QueryEscape(v))
+		}
+		chunk = append(chunk, v)
+		length += l
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func joinedLen(values []string) int {
+	return len(url.QueryEscape(strings.Join(values, ",")))
+}
@@ -0,0 +1,94 @@
+package gocko
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClient_SimplePriceBatch(t *testing.T) {
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, fmt.Sprintf("%s-%d", strings.Repeat("x", i%20+1), i))
+	}
+	failure := errors.New("boom")
+	var mu sync.Mutex
+	requested := map[string]int{}
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		q := r.URL.Query()
+		require.LessOrEqual(t, len(r.URL.RawQuery), 2000+200)
+		prices := map[string]map[string]float64{}
+		for _, id := range strings.Split(q.Get("ids"), ",") {
+			mu.Lock()
+			requested[id]++
+			mu.Unlock()
+			if id == ids[999] {
+				return nil, failure
+			}
+			prices[id] = map[string]float64{"last_updated_at": 1626898796}
+			for _, vsc := range strings.Split(q.Get("vs_currencies"), ",") {
+				prices[id][vsc] = float64(len(id))
+			}
+		}
+		return jsonResponse(prices)
+	})}
+	c := NewClient(WithHttpClient(hc))
+	sps, err := c.SimplePriceBatch(SimplePriceBatchParams{
+		SimplePriceParams: SimplePriceParams{Ids: ids, VsCurrencies: []string{"usd", "eur"}},
+		Concurrency:       3,
+	})
+	var ces ChunkErrors
+	require.ErrorAs(t, err, &ces)
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, 1, len(ces))
+	require.Contains(t, ces[0].Ids, ids[999])
+	require.Equal(t, 1000-len(ces[0].Ids), len(sps.Prices))
+	require.Equal(t, 3.0, sps.Prices["x-0"].CurrencyPrice["eur"].Price)
+	require.Equal(t, 1000, len(requested))
+	for _, n := range requested {
+		require.Equal(t, 1, n)
+	}
+
+	_, err = c.SimplePriceBatch(SimplePriceBatchParams{SimplePriceParams: SimplePriceParams{Ids: ids}})
+	require.ErrorIs(t, err, MissingParameterError)
+}
+
+func TestClient_SimpleTokenPriceBatch(t *testing.T) {
+	var addresses []string
+	for i := 0; i < 200; i++ {
+		addresses = append(addresses, fmt.Sprintf("0x%040x", i))
+	}
+	var mu sync.Mutex
+	requests := 0
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		if r.URL.Path != "/api/v3/simple/token_price/ethereum" || len(r.URL.RawQuery) > 2000+200 {
+			return nil, fmt.Errorf("unexpected request %s", r.URL)
+		}
+		prices := map[string]map[string]float64{}
+		for _, address := range strings.Split(r.URL.Query().Get("contract_addresses"), ",") {
+			prices[address] = map[string]float64{"usd": 1}
+		}
+		return jsonResponse(prices)
+	})}
+	c := NewClient(WithHttpClient(hc))
+	sps, err := c.SimpleTokenPriceBatch(SimpleTokenPriceBatchParams{SimpleTokenPriceParams: SimpleTokenPriceParams{
+		Id: "ethereum", ContractAddresses: addresses, VsCurrencies: []string{"usd"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, len(addresses), len(sps.Prices))
+	require.Equal(t, 1.0, sps.Prices[addresses[199]].CurrencyPrice["usd"].Price)
+	require.Greater(t, requests, 1)
+}
+
+func TestChunkJoined(t *testing.T) {
+	chunks := chunkJoined([]string{"aaaa", "bbbb", "cccc", "dddddddddddd", "e"}, 11)
+	require.Equal(t, [][]string{{"aaaa", "bbbb"}, {"cccc"}, {"dddddddddddd"}, {"e"}}, chunks)
+	require.Nil(t, chunkJoined(nil, 10))
+}
@@ -1,14 +1,27 @@
 package gocko
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const baseURL = "https://api.coingecko.com/api/v3"
 
 type Client struct {
-	httpClient *http.Client
+	httpClient  *http.Client
+	baseURL     string
+	limiter     *rateLimiter
+	decimals    bool
+	flights     *flightGroup
+	middlewares []Middleware
+	logger      Logger
+	last        *lastMeta // shared with the copies made by WithMeta
+	meta        *callMeta // filled by each call of a WithMeta copy
 }
 
 type Option func(*Client)
@@ -16,6 +29,8 @@ type Option func(*Client)
 func NewClient(options ...Option) *Client {
 	c := &Client{
 		httpClient: http.DefaultClient,
+		baseURL:    baseURL,
+		last:       &lastMeta{},
 	}
 	for _, option := range options {
 		option(c)
@@ -24,13 +39,78 @@ func NewClient(options ...Option) *Client {
 }
 func WithHttpClient(hc *http.Client) Option { return func(c *Client) { c.httpClient = hc } }
 
+// WithBaseURL replaces https://api.coingecko.com/api/v3, eg. with a proxy or the pro API.
+func WithBaseURL(url string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimSuffix(url, "/") }
+}
+
+// WithDecimalNumbers additionally decodes monetary values of Market, SimplePrices and Charts
+// into their Exact fields, keeping the exact number text of the payload.
+func WithDecimalNumbers() Option { return func(c *Client) { c.decimals = true } }
+
+// WithRateLimit spaces requests so that at most callsPerMinute are issued, it's shared by all goroutines.
+// The spacing grows when the x-ratelimit-remaining of a CoinGecko response reports fewer calls left than it
+// would issue before the reset, the headers of other APIs such as GeckoTerminal are ignored.
+func WithRateLimit(callsPerMinute int) Option {
+	return func(c *Client) {
+		if callsPerMinute > 0 {
+			c.limiter = newRateLimiter(callsPerMinute)
+		}
+	}
+}
+
+// WithCoalescing makes concurrent calls with the same final URL share one round trip. Each caller still
+// decodes the payload into its own result, so nothing is shared between them.
+func WithCoalescing() Option {
+	return func(c *Client) { c.flights = &flightGroup{calls: map[string]*flight{}} }
+}
+
+// WithMiddleware wraps every call with mws, the first one being the outermost.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(c *Client) { c.middlewares = append(c.middlewares, mws...) }
+}
+
+// WithLogger logs each round trip at debug level and the payload anomalies worked around at warn level.
+func WithLogger(l Logger) Option { return func(c *Client) { c.logger = l } }
+
+func (c *Client) BaseURL() string { return c.baseURL }
+
+// StatusError is returned for non 2xx responses, Body is the payload CoinGecko sent along.
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), strings.TrimSpace(string(e.Body)))
+}
+
+// DecodeError is returned when the payload doesn't fit the result.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string { return fmt.Sprintf("decoding response: %s", e.Err) }
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// Do requests the absolute url, middlewares see its path relative to the base URL as the endpoint.
 func (c *Client) Do(url string, params QueryParams, ptr interface{}) error {
-	req, err := http.NewRequest("GET", url, nil)
+	return c.do(endpointOf(strings.TrimPrefix(url, c.baseURL)), url, params, ptr)
+}
+
+func (c *Client) do(endpoint, url string, params QueryParams, ptr interface{}) error {
+	return c.doContext(context.Background(), endpoint, url, params, ptr)
+}
+
+// doContext runs the middlewares around the round trip, invalid params are reported before reaching them.
+func (c *Client) doContext(ctx context.Context, endpoint, url string, params QueryParams, ptr interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return err
 	}
 	if params != nil {
-		qp, err := params.toQuery()
+		qp, err := params.Query()
 		if err != nil {
 			return err
 		}
@@ -40,24 +120,118 @@ func (c *Client) Do(url string, params QueryParams, ptr interface{}) error {
 		}
 		req.URL.RawQuery = q.Encode()
 	}
+	next := c.roundTrip
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+	return next(&Call{Endpoint: endpoint, Params: params, Request: req, Result: ptr})
+}
+
+func (c *Client) roundTrip(call *Call) error {
+	start := time.Now()
+	var res response
+	var shared bool
+	var err error
+	if c.flights != nil {
+		res, shared, err = c.flights.do(call.Request.Context(), call.Request.URL.String(), func(ctx context.Context) (response, error) {
+			return c.fetch(call.Request.WithContext(ctx))
+		})
+	} else {
+		res, err = c.fetch(call.Request)
+	}
+	call.StatusCode, call.RateLimitWait, call.Meta = res.status, res.waited, res.meta
+	if c.meta != nil && res.meta != nil {
+		c.meta.set(res.meta)
+	}
+	if err == nil {
+		err = c.decode(res.body, call.Result)
+	}
+	if c.logger != nil {
+		args := []interface{}{"endpoint", call.Endpoint, "url", redact(call.Request.URL), "status", res.status,
+			"duration", time.Since(start), "rate_limit_wait", res.waited, "shared", shared}
+		if res.meta != nil && len(res.meta.CacheStatus) > 0 {
+			args = append(args, "cache", res.meta.CacheStatus)
+		}
+		if err != nil {
+			args = append(args, "error", err)
+		}
+		c.logger.Debug("gocko request", args...)
+	}
+	return err
+}
+
+func (c *Client) decode(bs []byte, ptr interface{}) error {
+	if err := json.Unmarshal(bs, ptr); err != nil {
+		return &DecodeError{Err: err}
+	}
+	if ed, ok := ptr.(exactDecoder); ok && c.decimals {
+		if err := ed.unmarshalExact(bs); err != nil {
+			return &DecodeError{Err: err}
+		}
+	}
+	return nil
+}
+
+type response struct {
+	status int
+	body   []byte
+	waited time.Duration
+	meta   *ResponseMeta
+}
+
+func (c *Client) fetch(req *http.Request) (response, error) {
+	var waited time.Duration
+	if c.limiter != nil {
+		var err error
+		if waited, err = c.limiter.wait(req.Context()); err != nil {
+			return response{waited: waited}, err
+		}
+	}
+	start := time.Now()
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return response{waited: waited}, err
 	}
-	return json.NewDecoder(res.Body).Decode(ptr)
+	defer res.Body.Close()
+	bs, err := io.ReadAll(res.Body)
+	meta := newResponseMeta(res, time.Since(start))
+	c.last.set(meta)
+	// the quota of other APIs, eg. GeckoTerminal for Onchain, doesn't tell how fast to call CoinGecko
+	if c.limiter != nil && meta.RateLimitRemaining >= 0 && strings.HasPrefix(req.URL.String(), c.baseURL+"/") {
+		c.limiter.observe(meta.RateLimitRemaining, meta.RateLimitReset)
+	}
+	r := response{status: res.StatusCode, waited: waited, meta: meta}
+	if err != nil {
+		return r, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return r, &StatusError{StatusCode: res.StatusCode, Body: bs}
+	}
+	r.body = bs
+	return r, nil
+}
+
+// exactDecoder is implemented by the models having Decimal counterparts.
+type exactDecoder interface {
+	unmarshalExact([]byte) error
 }
 
 type Api interface {
 	Ping() (Ping, error)
 	SimpleSupportedVsCurrencies() ([]string, error)
 	SimplePrice(SimplePriceParams) (SimplePrices, error)
+	SimpleTokenPrice(SimpleTokenPriceParams) (SimplePrices, error)
 	CoinsList(CoinsParams) ([]Coin, error)
 	CoinsMarkets(CoinsMarketsParams) ([]Market, error)
 	CoinsID(CoinsDataParams) (CoinData, error)
+	CoinsHistory(CoinsHistoryParams) (CoinHistory, error)
+	CoinsStatusUpdates(CoinsStatusUpdatesParams) ([]StatusUpdate, error)
 	CoinsMarketCharts(CoinsChartsParams) (Charts, error)
+	CoinsMarketChartsRange(CoinsChartsRangeParams) (Charts, error)
 	CoinsOHLC(CoinsOHLCParams) (OHLC, error)
 	ExchangesList() (ExchangeList, error)
 	Exchanges(params ExchangesParams) ([]Exchange, error)
+	StatusUpdates(StatusUpdatesParams) ([]StatusUpdate, error)
 }
 
 func assertApiInterface() {
@@ -0,0 +1,43 @@
+// Command gocko-exporter serves CoinGecko market data of a set of coins on /metrics for Prometheus.
+//
+//	gocko-exporter -ids bitcoin,ethereum -vs usd,eur -listen :9101
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/esenmx/gocko"
+	"github.com/esenmx/gocko/exporter"
+)
+
+func main() {
+	ids := flag.String("ids", "bitcoin,ethereum", "coin ids, comma separated")
+	vscs := flag.String("vs", "usd", "vs currencies, comma separated")
+	interval := flag.Duration("interval", time.Minute, "collection interval")
+	listen := flag.String("listen", ":9101", "listen address")
+	rateLimit := flag.Int("rate-limit", 10, "max CoinGecko calls per minute")
+	flag.Parse()
+
+	c := gocko.NewClient(gocko.WithRateLimit(*rateLimit))
+	e := exporter.New(c, exporter.Config{Ids: split(*ids), VsCurrencies: split(*vscs), Interval: *interval})
+	go e.Run(context.Background(), func(err error) { log.Println("collect:", err) })
+
+	http.Handle("/metrics", e)
+	log.Printf("listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
+
+func split(s string) []string {
+	var vs []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
@@ -0,0 +1,30 @@
+// Command gocko-proxy is a caching CoinGecko proxy shared by many clients.
+//
+//	gocko-proxy -listen :8080 -rate-limit 30
+//
+// Clients use gocko.WithBaseURL("http://localhost:8080/api/v3").
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/esenmx/gocko"
+	"github.com/esenmx/gocko/proxy"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "listen address")
+	upstream := flag.String("upstream", "https://api.coingecko.com/api/v3", "CoinGecko base URL")
+	rateLimit := flag.Int("rate-limit", 10, "max upstream calls per minute")
+	defaultTTL := flag.Duration("ttl", time.Minute, "TTL of routes without a default")
+	maxEntries := flag.Int("max-entries", 10000, "max cached responses")
+	flag.Parse()
+
+	c := gocko.NewClient(gocko.WithBaseURL(*upstream), gocko.WithRateLimit(*rateLimit))
+	p := proxy.New(c, proxy.Options{DefaultTTL: *defaultTTL, MaxEntries: *maxEntries})
+	log.Printf("listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, p))
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/esenmx/gocko"
+)
+
+func ping(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error) {
+	if err := fs.Parse(args); err != nil {
+		return result{}, err
+	}
+	p, err := c.Ping()
+	return result{value: p, header: []string{"gecko_says"}, rows: [][]string{{p.GeckoSays}}}, err
+}
+
+func price(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error) {
+	var p gocko.SimplePriceParams
+	fs.Var((*csvFlag)(&p.Ids), "ids", "coin ids, comma separated (required)")
+	fs.Var((*csvFlag)(&p.VsCurrencies), "vs", "vs currencies, comma separated (required)")
+	fs.BoolVar(&p.IncludeMarketCap, "market-cap", false, "include market cap")
+	fs.BoolVar(&p.Include24hrVol, "vol", false, "include 24h volume")
+	fs.BoolVar(&p.Include24hrChange, "change", false, "include 24h change")
+	fs.BoolVar(&p.IncludeLastUpdatedAt, "last-updated", false, "include last updated at")
+	if err := fs.Parse(args); err != nil {
+		return result{}, err
+	}
+	sps, err := c.SimplePrice(p)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{value: sps.Prices, header: []string{"id", "vs_currency", "price", "market_cap", "24h_vol", "24h_change", "last_updated_at"}}
+	for _, id := range sortedKeys(sps.Prices) {
+		sp := sps.Prices[id]
+		updatedAt := ""
+		if p.IncludeLastUpdatedAt && sp.LastUpdatedAt != nil {
+			updatedAt = timestamp(time.Unix(*sp.LastUpdatedAt, 0))
+		}
+		for _, vsc := range p.VsCurrencies {
+			pr, ok := sp.CurrencyPrice[vsc]
+			if !ok {
+				continue
+			}
+			res.rows = append(res.rows, []string{id, vsc, float(pr.Price), floatPtr(pr.MarketCap), floatPtr(pr.Vol24h),
+				floatPtr(pr.Change24h), updatedAt})
+		}
+	}
+	return res, nil
+}
+
+func markets(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error) {
+	var p gocko.CoinsMarketsParams
+	var order string
+	var windows []string
+	fs.StringVar(&p.VsCurrency, "vs", "usd", "vs currency")
+	fs.Var((*csvFlag)(&p.Ids), "ids", "coin ids, comma separated")
+	fs.StringVar(&p.Category, "category", "", "category, eg. stablecoins")
+	fs.StringVar(&order, "order", "", "gecko_desc, gecko_asc, market_cap_asc, market_cap_desc, volume_asc, volume_desc, id_asc, id_desc")
+	fs.IntVar(&p.PerPage, "per-page", 0, "results per page, max 250")
+	fs.IntVar(&p.Page, "page", 0, "page")
+	fs.Var((*csvFlag)(&windows), "price-change", "price change windows, comma separated: 1h, 24h, 7d, 14d, 30d, 200d, 1y")
+	fs.BoolVar(&p.Sparkline, "sparkline", false, "include the 7d sparkline")
+	if err := fs.Parse(args); err != nil {
+		return result{}, err
+	}
+	p.Order = gocko.Order(order)
+	for _, w := range windows {
+		p.PriceChangePercentage = append(p.PriceChangePercentage, gocko.PriceChangeWindow(w))
+	}
+	ms, err := c.CoinsMarkets(p)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{value: ms, header: []string{"rank", "id", "symbol", "name", "price", "market_cap", "total_volume", "change_24h"}}
+	for _, m := range ms {
+		res.rows = append(res.rows, []string{integer(m.MarketCapRank), m.Id, m.Symbol, m.Name, float(m.CurrentPrice),
+			float(m.MarketCap), float(m.TotalVolume), float(m.PriceChangePercentage24H)})
+	}
+	return res, nil
+}
+
+func coin(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error) {
+	var p gocko.CoinsDataParams
+	fs.StringVar(&p.Id, "id", "", "coin id (required)")
+	if err := fs.Parse(args); err != nil {
+		return result{}, err
+	}
+	cd, err := c.CoinsID(p)
+	if err != nil {
+		return result{}, err
+	}
+	return result{value: cd, header: []string{"field", "value"}, rows: [][]string{
+		{"id", cd.Id},
+		{"symbol", cd.Symbol},
+		{"name", cd.Name},
+		{"asset_platform_id", strPtr(cd.AssetPlatformId)},
+		{"hashing_algorithm", cd.HashingAlgorithm},
+		{"categories", strings.Join(cd.Categories, ", ")},
+		{"genesis_date", cd.GenesisDate},
+		{"market_cap_rank", integer(cd.MarketCapRank)},
+		{"coingecko_rank", integer(cd.CoingeckoRank)},
+		{"last_updated", timestamp(cd.LastUpdated)},
+	}}, nil
+}
+
+func chart(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error) {
+	var p gocko.CoinsChartsParams
+	var days string
+	var from, to string
+	fs.StringVar(&p.Id, "id", "", "coin id (required)")
+	fs.StringVar(&p.VsCurrency, "vs", "usd", "vs currency")
+	fs.StringVar(&days, "days", "1", "number of days or max")
+	fs.StringVar(&from, "from", "", "range start, RFC 3339 or 2006-01-02, replaces -days")
+	fs.StringVar(&to, "to", "", "range end, RFC 3339 or 2006-01-02, defaults to now")
+	if err := fs.Parse(args); err != nil {
+		return result{}, err
+	}
+	var ccs gocko.Charts
+	var err error
+	if len(from) > 0 {
+		rp := gocko.CoinsChartsRangeParams{Id: p.Id, VsCurrency: p.VsCurrency, To: time.Now()}
+		if rp.From, err = parseTime(from); err != nil {
+			return result{}, err
+		}
+		if len(to) > 0 {
+			if rp.To, err = parseTime(to); err != nil {
+				return result{}, err
+			}
+		}
+		ccs, err = c.CoinsMarketChartsRange(rp)
+	} else {
+		p.Days = gocko.Days(days)
+		ccs, err = c.CoinsMarketCharts(p)
+	}
+	if err != nil {
+		return result{}, err
+	}
+	rows, err := ccs.Rows()
+	if err != nil {
+		return result{}, err
+	}
+	res := result{value: rows, header: []string{"time", "price", "market_cap", "volume"}}
+	for _, r := range rows {
+		res.rows = append(res.rows, []string{timestamp(r.Time), float(r.Price), float(r.MarketCap), float(r.Volume)})
+	}
+	return res, nil
+}
+
+func ohlc(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error) {
+	var p gocko.CoinsOHLCParams
+	var days string
+	var strict bool
+	fs.StringVar(&p.Id, "id", "", "coin id (required)")
+	fs.StringVar(&p.VsCurrency, "vs", "usd", "vs currency")
+	fs.StringVar(&days, "days", "1", "1, 7, 14, 30, 90, 180, 365 or max")
+	fs.BoolVar(&strict, "strict", false, "fail on inconsistent candles instead of widening high and low")
+	if err := fs.Parse(args); err != nil {
+		return result{}, err
+	}
+	p.Days = gocko.Days(days)
+	o, err := c.CoinsOHLC(p)
+	if err != nil {
+		return result{}, err
+	}
+	cs, err := o.Candles(strict)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{value: cs, header: []string{"time", "open", "high", "low", "close"}}
+	for _, cd := range cs {
+		res.rows = append(res.rows, []string{timestamp(cd.Time), float(cd.Open), float(cd.High), float(cd.Low), float(cd.Close)})
+	}
+	return res, nil
+}
+
+func exchanges(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error) {
+	var p gocko.ExchangesParams
+	fs.IntVar(&p.PerPage, "per-page", 100, "results per page, max 250")
+	fs.IntVar(&p.Page, "page", 1, "page")
+	if err := fs.Parse(args); err != nil {
+		return result{}, err
+	}
+	es, err := c.Exchanges(p)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{value: es, header: []string{"rank", "id", "name", "country", "year", "trust_score", "volume_24h_btc"}}
+	for _, e := range es {
+		res.rows = append(res.rows, []string{intPtr(e.TrustScoreRank), e.Id, e.Name, strPtr(e.Country),
+			intPtr(e.YearEstablished), intPtr(e.TrustScore), floatPtr(e.TradeVolume24HBtc)})
+	}
+	return res, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return t, fmt.Errorf("invalid time %q, use RFC 3339 or 2006-01-02", s)
+	}
+	return t, nil
+}
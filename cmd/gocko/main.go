@@ -0,0 +1,115 @@
+// Command gocko queries the CoinGecko API from the command line.
+//
+//	gocko <command> [flags]
+//
+// Commands are ping, price, markets, coin, chart, ohlc and exchanges, run `gocko <command> -h` for their flags.
+// Every command accepts -o table|json|csv.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/esenmx/gocko"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(c *gocko.Client, fs *flag.FlagSet, args []string) (result, error)
+}
+
+var commands = []command{
+	{"ping", "check the API status", ping},
+	{"price", "current prices of coins (simple/price)", price},
+	{"markets", "market data of coins (coins/markets)", markets},
+	{"coin", "details of a coin (coins/{id})", coin},
+	{"chart", "price, market cap and volume history (coins/{id}/market_chart)", chart},
+	{"ohlc", "candles of a coin (coins/{id}/ohlc)", ohlc},
+	{"exchanges", "exchanges ranked by trust score (exchanges)", exchanges},
+}
+
+func main() {
+	err := run(os.Args[1:], gocko.NewClient(), os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gocko:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, c *gocko.Client, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		usage(stderr)
+		return flag.ErrHelp
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		fs := flag.NewFlagSet("gocko "+cmd.name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		format := formatFlag("table")
+		fs.Var(&format, "o", "output format: table, json or csv")
+		res, err := cmd.run(c, fs, args[1:])
+		if err != nil {
+			return err
+		}
+		return write(stdout, string(format), res)
+	}
+	usage(stderr)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: gocko <command> [flags]")
+	fmt.Fprintln(w)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+// formatFlag is checked while parsing, before any request is spent.
+type formatFlag string
+
+func (f *formatFlag) String() string { return string(*f) }
+
+func (f *formatFlag) Set(s string) error {
+	switch s {
+	case "table", "json", "csv":
+		*f = formatFlag(s)
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q", s)
+}
+
+// csvFlag is a comma separated list flag.
+type csvFlag []string
+
+func (f *csvFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *csvFlag) Set(s string) error {
+	*f = nil
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			*f = append(*f, v)
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]gocko.SimplePrice) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
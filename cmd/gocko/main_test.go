@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/esenmx/gocko"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func testClient(payloads map[string]string) *gocko.Client {
+	return gocko.NewClient(gocko.WithHttpClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, ok := payloads[r.URL.Path]
+		if !ok {
+			return nil, fmt.Errorf("unexpected request %s", r.URL)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}))
+}
+
+func TestRun(t *testing.T) {
+	c := testClient(map[string]string{
+		"/api/v3/ping":                `{"gecko_says":"(V3) To the Moon!"}`,
+		"/api/v3/simple/price":        `{"solana":{"usd":25.63,"usd_24h_change":1.5},"polkadot":{"usd":12.2,"usd_24h_change":-2}}`,
+		"/api/v3/coins/polkadot/ohlc": `[[1648425600000,22.14,22.52,21.98,22.46],[1648440000000,22.46,22.81,22.31,22.69]]`,
+	})
+	var out, errOut bytes.Buffer
+	require.NoError(t, run([]string{"ping", "-o", "json"}, c, &out, &errOut))
+	require.Equal(t, "{\n  \"gecko_says\": \"(V3) To the Moon!\"\n}\n", out.String())
+
+	out.Reset()
+	require.NoError(t, run([]string{"price", "-ids", "polkadot,solana", "-vs", "usd", "-change", "-o", "csv"}, c, &out, &errOut))
+	require.Equal(t, "id,vs_currency,price,market_cap,24h_vol,24h_change,last_updated_at\n"+
+		"polkadot,usd,12.2,,,-2,\n"+
+		"solana,usd,25.63,,,1.5,\n", out.String())
+
+	out.Reset()
+	require.NoError(t, run([]string{"ohlc", "-id", "polkadot", "-days", "1"}, c, &out, &errOut))
+	require.Equal(t, "TIME                  OPEN   HIGH   LOW    CLOSE\n"+
+		"2022-03-28T00:00:00Z  22.14  22.52  21.98  22.46\n"+
+		"2022-03-28T04:00:00Z  22.46  22.81  22.31  22.69\n", out.String())
+
+	require.ErrorIs(t, run([]string{"ohlc", "-id", "polkadot", "-days", "2"}, c, &out, &errOut), gocko.InvalidParameterError)
+	require.ErrorIs(t, run([]string{"price", "-vs", "usd"}, c, &out, &errOut), gocko.MissingParameterError)
+	// the format is rejected before the request is spent
+	unused := gocko.NewClient(gocko.WithHttpClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request %s", r.URL)
+		return nil, fmt.Errorf("unexpected request %s", r.URL)
+	})}))
+	require.Error(t, run([]string{"price", "-ids", "polkadot", "-vs", "usd", "-o", "xml"}, unused, &out, &errOut))
+	require.Contains(t, errOut.String(), `unknown output format "xml"`)
+	require.Error(t, run([]string{"moon"}, c, &out, &errOut))
+	require.ErrorIs(t, run(nil, c, &out, &errOut), flag.ErrHelp)
+	require.Contains(t, errOut.String(), "exchanges")
+}
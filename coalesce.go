@@ -0,0 +1,73 @@
+package gocko
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup lets concurrent fetches of the same URL share one round trip.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	res     response
+	err     error
+	waiters int // callers still waiting, the fetch is canceled when the last one gives up
+	cancel  context.CancelFunc
+}
+
+// do calls fetch once for all callers of key arriving while it runs, they all receive the same body,
+// which must be treated as read only. shared reports whether the caller joined another one's call.
+// fetch runs on its own goroutine so each caller only gives up on ctx, the others still get the response.
+// Its context carries the values of the first caller's ctx and is canceled once every caller gave up.
+func (g *flightGroup) do(ctx context.Context, key string, fetch func(context.Context) (response, error)) (res response, shared bool, err error) {
+	g.mu.Lock()
+	f, shared := g.calls[key]
+	if !shared {
+		var fctx context.Context
+		f = &flight{done: make(chan struct{})}
+		fctx, f.cancel = context.WithCancel(detached{ctx})
+		g.calls[key] = f
+		go func() {
+			f.res, f.err = fetch(fctx)
+			g.mu.Lock()
+			g.forget(key, f)
+			g.mu.Unlock()
+			f.cancel()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+	select {
+	case <-f.done:
+		return f.res, shared, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		if f.waiters--; f.waiters == 0 {
+			// later callers start over instead of joining the abandoned fetch
+			g.forget(key, f)
+			f.cancel()
+		}
+		g.mu.Unlock()
+		return response{}, shared, ctx.Err()
+	}
+}
+
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+}
+
+// detached keeps the values of a context, eg. trace spans, without its deadline and cancellation,
+// so a shared fetch isn't tied to the caller that started it.
+type detached struct{ context.Context }
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detached) Done() <-chan struct{}       { return nil }
+func (detached) Err() error                  { return nil }
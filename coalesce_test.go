@@ -0,0 +1,66 @@
+package gocko
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Coalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return jsonResponse(map[string]map[string]float64{"bitcoin": {"usd": 1}})
+	})}
+	c := NewClient(WithHttpClient(hc), WithCoalescing())
+
+	results := make([]SimplePrices, 5)
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.SimplePrice(SimplePriceParams{Ids: []string{"bitcoin"}, VsCurrencies: []string{"usd"}})
+		}(i)
+	}
+	// release the round trip once every caller is waiting on it
+	waitFlight(c, len(results))
+	close(release)
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	for _, sps := range results {
+		require.Equal(t, 1.0, sps.Prices["bitcoin"].CurrencyPrice["usd"].Price)
+	}
+	// every caller decoded its own copy
+	results[0].Prices["bitcoin"].CurrencyPrice["usd"] = Price{Price: 2}
+	require.Equal(t, 1.0, results[1].Prices["bitcoin"].CurrencyPrice["usd"].Price)
+
+	// calls that don't overlap aren't coalesced
+	_, err := c.SimplePrice(SimplePriceParams{Ids: []string{"bitcoin"}, VsCurrencies: []string{"usd"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+// waitFlight waits for a call in flight with the given number of waiting callers.
+func waitFlight(c *Client, waiters int) {
+	for {
+		c.flights.mu.Lock()
+		for _, f := range c.flights.calls {
+			if f.waiters == waiters {
+				c.flights.mu.Unlock()
+				return
+			}
+		}
+		c.flights.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+}
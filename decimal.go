@@ -0,0 +1,215 @@
+package gocko
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var InvalidDecimalError = errors.New("invalid decimal")
+
+// maxExponent bounds the exponent of parsed numbers, far beyond any price yet small enough to keep
+// payloads from making normalize allocate huge powers of ten.
+const maxExponent = 1000
+
+// Decimal is an exact base 10 number, the value is unscaled * 10^-scale. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mantissa = s[:i]
+		exp, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || exp > maxExponent || exp < -maxExponent {
+			return Decimal{}, InvalidDecimalError
+		}
+	}
+	digits := mantissa
+	scale := int64(0)
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		scale = int64(len(mantissa) - i - 1)
+	}
+	if len(strings.TrimLeft(digits, "+-")) == 0 {
+		return Decimal{}, InvalidDecimalError
+	}
+	if scale-exp > math.MaxInt32 {
+		return Decimal{}, InvalidDecimalError
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, InvalidDecimalError
+	}
+	return normalize(unscaled, scale-exp), nil
+}
+
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimalFromFloat uses the shortest representation that round trips to f.
+func NewDecimalFromFloat(f float64) Decimal {
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+func NewDecimalFromInt(i int64) Decimal {
+	return Decimal{unscaled: big.NewInt(i)}
+}
+
+func normalize(unscaled *big.Int, scale int64) Decimal {
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale returns the unscaled value of d at the given, not smaller, scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	i := new(big.Int).Set(d.int())
+	if scale > d.scale {
+		i.Mul(i, pow10(int64(scale-d.scale)))
+	}
+	return i
+}
+
+func (d Decimal) align(o Decimal) (*big.Int, *big.Int, int32) {
+	scale := d.scale
+	if o.scale > scale {
+		scale = o.scale
+	}
+	return d.rescale(scale), o.rescale(scale), scale
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := d.align(o)
+	return Decimal{unscaled: a.Add(a, b), scale: scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := d.align(o)
+	return Decimal{unscaled: a.Sub(a, b), scale: scale}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Div rounds half away from zero to the given number of fractional digits, it panics on division by zero
+// and on a negative scale.
+func (d Decimal) Div(o Decimal, scale int32) Decimal {
+	if o.Sign() == 0 {
+		panic("gocko: decimal division by zero")
+	}
+	if scale < 0 {
+		panic("gocko: negative decimal division scale")
+	}
+	// d/o = (d.unscaled * 10^(scale + o.scale - d.scale)) / o.unscaled, at one extra digit for rounding
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(o.int())
+	shift := int64(scale) + 1 + int64(o.scale) - int64(d.scale)
+	if shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	q := num.Quo(num, den)
+	r := new(big.Int)
+	q.QuoRem(q, big.NewInt(10), r)
+	if r.CmpAbs(big.NewInt(5)) >= 0 {
+		if r.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Decimal{unscaled: q, scale: scale}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{unscaled: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Cmp returns -1, 0 or +1 like big.Int.Cmp.
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := d.align(o)
+	return a.Cmp(b)
+}
+
+func (d Decimal) Equal(o Decimal) bool { return d.Cmp(o) == 0 }
+
+func (d Decimal) Sign() int { return d.int().Sign() }
+
+func (d Decimal) IsZero() bool { return d.Sign() == 0 }
+
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats d in plain notation keeping its scale, so a decoded JSON number without exponent is reproduced as is.
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		if d.scale < 0 && d.Sign() != 0 {
+			s += strings.Repeat("0", int(-d.scale))
+		}
+		return sign + s
+	}
+	if pad := int(d.scale) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	i := len(s) - int(d.scale)
+	return sign + s[:i] + "." + s[i:]
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts numbers and numeric strings, null leaves d untouched.
+func (d *Decimal) UnmarshalJSON(bs []byte) error {
+	s := string(bs)
+	if s == "null" {
+		return nil
+	}
+	if uq, err := strconv.Unquote(s); err == nil {
+		s = uq
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
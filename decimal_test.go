@@ -0,0 +1,90 @@
+package gocko
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	for in, out := range map[string]string{
+		"1820.88":           "1820.88",
+		"0.000000000001234": "0.000000000001234",
+		"1.5e-12":           "0.0000000000015",
+		"-2.50":             "-2.50",
+		"1E3":               "1000",
+		"213190216340":      "213190216340",
+		"0":                 "0",
+		"1e1000":            "1" + strings.Repeat("0", 1000),
+	} {
+		d, err := ParseDecimal(in)
+		require.NoError(t, err)
+		require.Equal(t, out, d.String())
+	}
+	for _, in := range []string{"", "-", "1.2.3", "e5", "1e", "abc", "1e1001", "0.5e-2147483647", "1e2147483647", "1e9999999999"} {
+		_, err := ParseDecimal(in)
+		require.ErrorIs(t, err, InvalidDecimalError, in)
+	}
+
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.2")
+	require.Equal(t, "0.3", a.Add(b).String())
+	require.Equal(t, "-0.1", a.Sub(b).String())
+	require.Equal(t, "0.02", a.Mul(b).String())
+	require.Equal(t, "0.50", a.Div(b, 2).String())
+	require.Equal(t, "0.6667", MustParseDecimal("2").Div(MustParseDecimal("3"), 4).String())
+	require.Equal(t, "-0.6667", MustParseDecimal("-2").Div(MustParseDecimal("3"), 4).String())
+	require.Equal(t, -1, a.Cmp(b))
+	require.True(t, MustParseDecimal("1.50").Equal(MustParseDecimal("1.5")))
+	require.True(t, Decimal{}.IsZero())
+	require.Equal(t, "0.1", Decimal{}.Add(a).String())
+	require.Equal(t, 1e-12, MustParseDecimal("1e-12").Float64())
+	require.Equal(t, "0.1", NewDecimalFromFloat(0.1).String())
+	require.Panics(t, func() { a.Div(Decimal{}, 2) })
+	require.Panics(t, func() { a.Div(b, -1) })
+	require.Equal(t, "1200", Decimal{unscaled: big.NewInt(12), scale: -2}.String())
+
+	var v struct {
+		A Decimal  `json:"a"`
+		B *Decimal `json:"b"`
+		C Decimal  `json:"c"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"a":0.00000000000123,"b":null,"c":"42.10"}`), &v))
+	require.Equal(t, "0.00000000000123", v.A.String())
+	require.Nil(t, v.B)
+	require.Equal(t, "42.10", v.C.String())
+	bs, err := json.Marshal(v.A)
+	require.NoError(t, err)
+	require.Equal(t, "0.00000000000123", string(bs))
+}
+
+func TestDecimalNumbers(t *testing.T) {
+	serve := func(filename string) *Client {
+		return NewClient(WithDecimalNumbers(), WithHttpClient(&http.Client{Transport: roundTripFunc(
+			func(r *http.Request) (*http.Response, error) { return fileResponse(filename) })}))
+	}
+	sps, err := serve("simple_price").SimplePrice(SimplePriceParams{Ids: []string{"polkadot", "solana"}, VsCurrencies: []string{"usd", "aud"}})
+	require.NoError(t, err)
+	dot := sps.Prices["polkadot"].CurrencyPrice["usd"]
+	require.Equal(t, "12.2", dot.Exact.Price.String())
+	require.Equal(t, "12307700548.971405", dot.Exact.MarketCap.String())
+	require.Nil(t, sps.Prices["solana"].CurrencyPrice["aud"].Exact.Vol24h)
+
+	ms, err := serve("coins_markets").CoinsMarkets(CoinsMarketsParams{VsCurrency: "usd"})
+	require.NoError(t, err)
+	require.Equal(t, "1820.88", ms[0].Exact.CurrentPrice.String())
+	require.Nil(t, ms[0].Exact.FullyDilutedValuation)
+
+	ccs, err := serve("coins_market_chart").CoinsMarketCharts(CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: Days1})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(ccs.Exact.Prices))
+	require.Equal(t, "22.516862164558325", ccs.Exact.Prices[0].Value.String())
+	require.Equal(t, ccs.PricePoints()[0].Time, ccs.Exact.Prices[0].Time)
+
+	ccs, err = NewClient(WithHttpClient(serve("coins_market_chart").httpClient)).
+		CoinsMarketCharts(CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: Days1})
+	require.NoError(t, err)
+	require.Nil(t, ccs.Exact)
+}
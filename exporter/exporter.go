@@ -0,0 +1,188 @@
+// Package exporter exposes CoinGecko market data of a set of coins in the Prometheus text exposition format.
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/esenmx/gocko"
+)
+
+const perPage = 250
+
+type Config struct {
+	Ids          []string      // required coin ids
+	VsCurrencies []string      // required
+	Interval     time.Duration // defaults to 1 minute, each interval issues one coins/markets call per currency and 250 ids
+}
+
+// Exporter keeps the latest CoinsMarkets snapshot and serves it on ServeHTTP.
+type Exporter struct {
+	c   *gocko.Client
+	cfg Config
+
+	mu         sync.RWMutex
+	markets    map[string][]gocko.Market // vs currency
+	lastScrape time.Time
+	scrapes    int
+	errors     int
+	up         bool
+}
+
+func New(c *gocko.Client, cfg Config) *Exporter {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	return &Exporter{c: c, cfg: cfg, markets: map[string][]gocko.Market{}}
+}
+
+// Collect refreshes the snapshot, on error the previous values of the failed currency are kept.
+func (e *Exporter) Collect() error {
+	var firstErr error
+	for _, vsc := range e.cfg.VsCurrencies {
+		var ms []gocko.Market
+		var err error
+		for i := 0; i < len(e.cfg.Ids) && err == nil; i += perPage {
+			end := i + perPage
+			if end > len(e.cfg.Ids) {
+				end = len(e.cfg.Ids)
+			}
+			var page []gocko.Market
+			page, err = e.c.CoinsMarkets(gocko.CoinsMarketsParams{
+				VsCurrency: vsc, Ids: e.cfg.Ids[i:end], PerPage: perPage,
+				// unlike price_change_percentage_24h, the in currency change decodes null as nil
+				PriceChangePercentage: []gocko.PriceChangeWindow{gocko.Window24h},
+			})
+			ms = append(ms, page...)
+		}
+		e.mu.Lock()
+		if err == nil {
+			e.markets[vsc] = ms
+		}
+		e.mu.Unlock()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	e.mu.Lock()
+	e.scrapes++
+	e.up = firstErr == nil
+	if firstErr != nil {
+		e.errors++
+	} else {
+		e.lastScrape = time.Now()
+	}
+	e.mu.Unlock()
+	return firstErr
+}
+
+// Run collects every Interval until ctx is done, errors are passed to onError when it's not nil.
+func (e *Exporter) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Collect(); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.WriteTo(w)
+}
+
+type metric struct {
+	name, help, kind string
+	samples          []string
+}
+
+func (m *metric) add(labels [][2]string, v float64) {
+	ls := make([]string, len(labels))
+	for i, l := range labels {
+		ls[i] = fmt.Sprintf(`%s="%s"`, l[0], escape(l[1]))
+	}
+	m.samples = append(m.samples, fmt.Sprintf("%s{%s} %s", m.name, strings.Join(ls, ","),
+		strconv.FormatFloat(v, 'g', -1, 64)))
+}
+
+// WriteTo writes the snapshot in the text exposition format.
+func (e *Exporter) WriteTo(w io.Writer) (int64, error) {
+	price := &metric{name: "gocko_price", help: "Current price of the coin.", kind: "gauge"}
+	marketCap := &metric{name: "gocko_market_cap", help: "Market capitalization of the coin.", kind: "gauge"}
+	volume := &metric{name: "gocko_total_volume", help: "Trading volume of the coin in the last 24 hours.", kind: "gauge"}
+	change := &metric{name: "gocko_price_change_percentage_24h", help: "Price change of the coin in the last 24 hours in percent.", kind: "gauge"}
+	rank := &metric{name: "gocko_market_cap_rank", help: "Market capitalization rank of the coin.", kind: "gauge"}
+	up := &metric{name: "gocko_up", help: "Whether the last collection succeeded.", kind: "gauge"}
+	scrapes := &metric{name: "gocko_collections_total", help: "Number of collections.", kind: "counter"}
+	errs := &metric{name: "gocko_collection_errors_total", help: "Number of failed collections.", kind: "counter"}
+	last := &metric{name: "gocko_last_collection_timestamp_seconds", help: "Time of the last successful collection.", kind: "gauge"}
+
+	e.mu.RLock()
+	vscs := make([]string, 0, len(e.markets))
+	for vsc := range e.markets {
+		vscs = append(vscs, vsc)
+	}
+	sort.Strings(vscs)
+	ranked := map[string]bool{}
+	for _, vsc := range vscs {
+		for _, m := range e.markets[vsc] {
+			labels := [][2]string{{"id", m.Id}, {"symbol", m.Symbol}, {"vs_currency", vsc}}
+			// null prices and market caps are decoded as 0
+			if m.CurrentPrice > 0 {
+				price.add(labels, m.CurrentPrice)
+			}
+			if m.MarketCap > 0 {
+				marketCap.add(labels, m.MarketCap)
+			}
+			volume.add(labels, m.TotalVolume)
+			if m.PriceChangePercentage24HInCurrency != nil {
+				change.add(labels, *m.PriceChangePercentage24HInCurrency)
+			}
+			if !ranked[m.Id] && m.MarketCapRank > 0 {
+				ranked[m.Id] = true
+				rank.add(labels[:2], float64(m.MarketCapRank))
+			}
+		}
+	}
+	upValue := 0.0
+	if e.up {
+		upValue = 1
+	}
+	up.samples = []string{fmt.Sprintf("%s %g", up.name, upValue)}
+	scrapes.samples = []string{fmt.Sprintf("%s %d", scrapes.name, e.scrapes)}
+	errs.samples = []string{fmt.Sprintf("%s %d", errs.name, e.errors)}
+	if !e.lastScrape.IsZero() {
+		last.samples = []string{fmt.Sprintf("%s %d", last.name, e.lastScrape.Unix())}
+	}
+	e.mu.RUnlock()
+
+	var n int64
+	for _, m := range []*metric{price, marketCap, volume, change, rank, up, scrapes, errs, last} {
+		if len(m.samples) == 0 {
+			continue
+		}
+		k, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s\n", m.name, m.help, m.name, m.kind, strings.Join(m.samples, "\n"))
+		n += int64(k)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
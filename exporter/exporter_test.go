@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/esenmx/gocko"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestExporter(t *testing.T) {
+	fail := false
+	c := gocko.NewClient(gocko.WithHttpClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/v3/coins/markets", r.URL.Path)
+		require.Equal(t, "ethereum,tether", r.URL.Query().Get("ids"))
+		require.Equal(t, "24h", r.URL.Query().Get("price_change_percentage"))
+		vsc := r.URL.Query().Get("vs_currency")
+		if fail && vsc == "eur" {
+			return nil, errors.New("boom")
+		}
+		price := map[string]float64{"usd": 1, "eur": 0.5}[vsc]
+		body := fmt.Sprintf(`[
+			{"id":"ethereum","symbol":"eth","name":"Ethereum","current_price":%g,"market_cap":213190216340,"market_cap_rank":2,"total_volume":16193626947,"price_change_percentage_24h":-3.82363,"price_change_percentage_24h_in_currency":-3.82363},
+			{"id":"tether","symbol":"usdt","name":"Tether \"USD\"","current_price":null,"market_cap":null,"market_cap_rank":null,"total_volume":5e10,"price_change_percentage_24h":null,"price_change_percentage_24h_in_currency":null}
+		]`, 1820.88*price)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}))
+	e := New(c, Config{Ids: []string{"ethereum", "tether"}, VsCurrencies: []string{"usd", "eur"}})
+	require.NoError(t, e.Collect())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	require.Contains(t, body, "# HELP gocko_price Current price of the coin.\n# TYPE gocko_price gauge\n"+
+		`gocko_price{id="ethereum",symbol="eth",vs_currency="eur"} 910.44`+"\n")
+	require.Contains(t, body, `gocko_price{id="ethereum",symbol="eth",vs_currency="usd"} 1820.88`)
+	require.Contains(t, body, `gocko_market_cap{id="ethereum",symbol="eth",vs_currency="usd"} 2.1319021634e+11`)
+	// null values aren't exported as 0
+	require.NotContains(t, body, `gocko_market_cap{id="tether"`)
+	require.NotContains(t, body, `gocko_price{id="tether"`)
+	require.NotContains(t, body, `gocko_price_change_percentage_24h{id="tether"`)
+	require.Contains(t, body, `gocko_total_volume{id="tether",symbol="usdt",vs_currency="usd"} 5e+10`)
+	require.Contains(t, body, `gocko_price_change_percentage_24h{id="ethereum",symbol="eth",vs_currency="usd"} -3.82363`)
+	require.Contains(t, body, "# TYPE gocko_market_cap_rank gauge\n"+`gocko_market_cap_rank{id="ethereum",symbol="eth"} 2`+"\n#")
+	require.Contains(t, body, "gocko_up 1\n")
+	require.Contains(t, body, "gocko_collections_total 1\n")
+	require.Contains(t, body, "gocko_last_collection_timestamp_seconds ")
+
+	fail = true
+	require.Error(t, e.Collect())
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body = rec.Body.String()
+	require.Contains(t, body, "gocko_up 0\n")
+	require.Contains(t, body, "gocko_collection_errors_total 1\n")
+	require.Contains(t, body, `gocko_price{id="ethereum",symbol="eth",vs_currency="eur"} 910.44`)
+}
+
+func TestEscape(t *testing.T) {
+	require.Equal(t, `a\\b\"c\nd`, escape("a\\b\"c\nd"))
+}
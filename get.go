@@ -0,0 +1,32 @@
+package gocko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GetRaw requests path relative to the base URL, eg. coins/bitcoin/tickers, and returns the payload undecoded,
+// giving access to endpoints and fields the models don't cover. params may be nil.
+func (c *Client) GetRaw(ctx context.Context, path string, params QueryParams) (json.RawMessage, error) {
+	return Get[json.RawMessage](ctx, c, path, params)
+}
+
+// endpointOf strips the query and surrounding slashes of path.
+func endpointOf(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return strings.Trim(path, "/")
+}
+
+// Get decodes the payload of path relative to the base URL into a T, going through the rate limit, coalescing
+// and middlewares of c like the built in endpoints. params may be nil.
+//
+//	tickers, err := gocko.Get[Tickers](ctx, c, "coins/bitcoin/tickers", gocko.QueryValues{"exchange_ids": "binance"})
+func Get[T any](ctx context.Context, c *Client, path string, params QueryParams) (T, error) {
+	var v T
+	err := c.doContext(ctx, endpointOf(path), fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(path, "/")), params, &v)
+	return v, err
+}
@@ -0,0 +1,128 @@
+package gocko
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_GetRaw(t *testing.T) {
+	var endpoints []string
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if err := r.Context().Err(); err != nil {
+			return nil, err
+		}
+		require.Equal(t, "/api/v3/coins/bitcoin/tickers", r.URL.Path)
+		require.Equal(t, "binance", r.URL.Query().Get("exchange_ids"))
+		return jsonResponse(map[string]interface{}{"name": "Bitcoin", "tickers": []interface{}{}})
+	})}
+	c := NewClient(WithHttpClient(hc), WithMiddleware(func(next DoFunc) DoFunc {
+		return func(call *Call) error {
+			endpoints = append(endpoints, call.Endpoint)
+			return next(call)
+		}
+	}))
+	raw, err := c.GetRaw(context.Background(), "/coins/bitcoin/tickers?exchange_ids=binance", nil)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"Bitcoin","tickers":[]}`, string(raw))
+	require.Equal(t, []string{"coins/bitcoin/tickers"}, endpoints)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetRaw(ctx, "coins/bitcoin/tickers?exchange_ids=binance", nil)
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
+type tickersParams struct {
+	Id          string
+	ExchangeIds []string
+}
+
+func (p tickersParams) Query() (map[string]string, error) {
+	if len(p.Id) == 0 {
+		return nil, ParamErrors{{Endpoint: "coins/{id}/tickers", Field: "Id", Reason: MissingParameterError}}
+	}
+	return map[string]string{"exchange_ids": strings.Join(p.ExchangeIds, ",")}, nil
+}
+
+func TestGet(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/v3/coins/bitcoin/tickers", r.URL.Path)
+		require.Equal(t, "binance,kraken", r.URL.Query().Get("exchange_ids"))
+		return jsonResponse(map[string]interface{}{"name": "Bitcoin", "tickers": []map[string]interface{}{{"base": "BTC", "last": 31000.5}}})
+	})}
+	c := NewClient(WithHttpClient(hc))
+	type tickers struct {
+		Name    string `json:"name"`
+		Tickers []struct {
+			Base string  `json:"base"`
+			Last float64 `json:"last"`
+		} `json:"tickers"`
+	}
+	ts, err := Get[tickers](context.Background(), c, "coins/bitcoin/tickers", tickersParams{Id: "bitcoin", ExchangeIds: []string{"binance", "kraken"}})
+	require.NoError(t, err)
+	require.Equal(t, "Bitcoin", ts.Name)
+	require.Equal(t, 31000.5, ts.Tickers[0].Last)
+
+	ts, err = Get[tickers](context.Background(), c, "coins/bitcoin/tickers", QueryValues{"exchange_ids": "binance,kraken"})
+	require.NoError(t, err)
+	require.Len(t, ts.Tickers, 1)
+
+	_, err = Get[tickers](context.Background(), c, "coins/bitcoin/tickers", tickersParams{})
+	require.True(t, errors.Is(err, MissingParameterError))
+}
+
+func TestGet_Context(t *testing.T) {
+	release := make(chan struct{})
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		<-release
+		return jsonResponse(map[string]string{"gecko_says": "(V3) To the Moon!"})
+	})}
+	c := NewClient(WithHttpClient(hc), WithCoalescing())
+
+	// the caller that started a shared call gives up, the one that joined still gets the response
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leader, follower := make(chan error, 1), make(chan error, 1)
+	go func() {
+		_, err := Get[Ping](leaderCtx, c, "ping", nil)
+		leader <- err
+	}()
+	waitFlight(c, 1)
+	go func() {
+		_, err := Get[Ping](context.Background(), c, "ping", nil)
+		follower <- err
+	}()
+	waitFlight(c, 2)
+	cancel()
+	require.ErrorIs(t, <-leader, context.Canceled)
+	close(release)
+	require.NoError(t, <-follower)
+
+	// once every caller gave up, the shared call stops waiting for its rate limit slot and isn't sent
+	var calls int32
+	hc = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return jsonResponse(map[string]string{"gecko_says": "(V3) To the Moon!"})
+	})}
+	c = NewClient(WithHttpClient(hc), WithCoalescing(), WithRateLimit(1))
+	_, err := Get[Ping](context.Background(), c, "ping", nil)
+	require.NoError(t, err)
+	c.limiter.mu.Lock()
+	next := c.limiter.next
+	c.limiter.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = Get[Ping](ctx, c, "ping", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Eventually(t, func() bool {
+		c.limiter.mu.Lock()
+		defer c.limiter.mu.Unlock()
+		return c.limiter.next.Equal(next)
+	}, time.Second, time.Millisecond)
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,36 @@
+package gocko
+
+import "net/url"
+
+// Logger takes alternating keys and values, *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+}
+
+// secretParams are the CoinGecko API key parameters.
+var secretParams = []string{"x_cg_pro_api_key", "x_cg_demo_api_key"}
+
+// redact hides the API keys passed as query parameters.
+func redact(u *url.URL) string {
+	q := u.Query()
+	redacted := false
+	for _, key := range secretParams {
+		if _, ok := q[key]; ok {
+			q.Set(key, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	r := *u
+	r.RawQuery = q.Encode()
+	return r.String()
+}
+
+func (c *Client) warn(msg string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Warn(msg, args...)
+	}
+}
@@ -0,0 +1,47 @@
+package gocko
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+type recordLogger struct{ debug, warn []string }
+
+func (l *recordLogger) Debug(msg string, args ...interface{}) {
+	l.debug = append(l.debug, fmt.Sprintln(append([]interface{}{msg}, args...)...))
+}
+
+func (l *recordLogger) Warn(msg string, args ...interface{}) {
+	l.warn = append(l.warn, fmt.Sprintln(append([]interface{}{msg}, args...)...))
+}
+
+func TestClient_Logger(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse([]Coin{{}, {Id: "bitcoin", Symbol: "btc", Name: "Bitcoin"}})
+	})}
+	l := &recordLogger{}
+	c := NewClient(WithHttpClient(hc), WithLogger(l))
+	cs, err := c.CoinsList(CoinsParams{})
+	require.NoError(t, err)
+	require.Equal(t, []Coin{{Id: "bitcoin", Symbol: "btc", Name: "Bitcoin"}}, cs)
+	require.Len(t, l.debug, 1)
+	require.Contains(t, l.debug[0], "endpoint coins/list")
+	require.Contains(t, l.debug[0], "status 200")
+	require.Len(t, l.warn, 1)
+	require.Contains(t, l.warn[0], "gocko dropped empty first element")
+
+	var raw []Coin
+	require.NoError(t, c.Do(c.BaseURL()+"/coins/list?x_cg_pro_api_key=secret", nil, &raw))
+	require.Contains(t, l.debug[1], "x_cg_pro_api_key=REDACTED")
+	require.NotContains(t, l.debug[1], "secret")
+}
+
+func TestClient_CoinsListEmpty(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Body: http.NoBody}, nil
+	})}
+	_, err := NewClient(WithHttpClient(hc)).CoinsList(CoinsParams{})
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package gocko
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResponseMeta describes the HTTP response a result was decoded from.
+type ResponseMeta struct {
+	StatusCode         int
+	Header             http.Header
+	Date               time.Time     // from the Date header, zero when missing
+	Age                time.Duration // time spent in the CDN cache, from the Age header
+	CacheStatus        string        // cf-cache-status, eg. HIT, MISS or EXPIRED
+	RateLimitRemaining int           // x-ratelimit-remaining, -1 when missing
+	RateLimitReset     time.Time     // x-ratelimit-reset, zero when missing
+	Duration           time.Duration // round trip including reading the body
+}
+
+func newResponseMeta(res *http.Response, duration time.Duration) *ResponseMeta {
+	m := &ResponseMeta{
+		StatusCode:         res.StatusCode,
+		Header:             res.Header,
+		CacheStatus:        res.Header.Get("Cf-Cache-Status"),
+		RateLimitRemaining: -1,
+		Duration:           duration,
+	}
+	if date, err := http.ParseTime(res.Header.Get("Date")); err == nil {
+		m.Date = date
+	}
+	if age, err := strconv.Atoi(res.Header.Get("Age")); err == nil {
+		m.Age = time.Duration(age) * time.Second
+	}
+	if reset, err := strconv.ParseInt(res.Header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+		// either a unix time or seconds from the server date
+		if reset > 1e9 {
+			m.RateLimitReset = time.Unix(reset, 0)
+		} else if !m.Date.IsZero() {
+			m.RateLimitReset = m.Date.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-Ratelimit-Remaining")); err == nil {
+		m.RateLimitRemaining = remaining
+	}
+	return m
+}
+
+// WithMeta returns a copy of c sharing its transport, rate limit and options, whose calls store the metadata
+// of their response in m. Use it for a single call at a time:
+//
+//	var meta gocko.ResponseMeta
+//	ms, err := c.WithMeta(&meta).CoinsMarkets(p)
+//	log.Println(meta.RateLimitRemaining, meta.CacheStatus)
+//
+// Helpers making concurrent calls, eg. SimplePriceBatch, leave the metadata of the latest response received.
+// m is left untouched when no response is received and must only be read once the call returned.
+func (c *Client) WithMeta(m *ResponseMeta) *Client {
+	cc := *c
+	cc.meta = &callMeta{meta: m}
+	return &cc
+}
+
+// callMeta serializes the writes of the concurrent calls made through one WithMeta copy.
+type callMeta struct {
+	mu   sync.Mutex
+	meta *ResponseMeta
+}
+
+func (cm *callMeta) set(m *ResponseMeta) {
+	cm.mu.Lock()
+	*cm.meta = *m
+	cm.mu.Unlock()
+}
+
+// LastResponseMeta returns the metadata of the latest response received by any call of c or its WithMeta copies.
+// Under concurrent use it may belong to another call, it's meant for monitoring the quota, use WithMeta otherwise.
+func (c *Client) LastResponseMeta() (ResponseMeta, bool) {
+	return c.last.get()
+}
+
+type lastMeta struct {
+	mu   sync.Mutex
+	meta *ResponseMeta
+}
+
+func (l *lastMeta) set(m *ResponseMeta) {
+	l.mu.Lock()
+	l.meta = m
+	l.mu.Unlock()
+}
+
+func (l *lastMeta) get() (ResponseMeta, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.meta == nil {
+		return ResponseMeta{}, false
+	}
+	return *l.meta, true
+}
@@ -0,0 +1,157 @@
+package gocko
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_ResponseMeta(t *testing.T) {
+	date := time.Date(2021, 7, 22, 10, 0, 0, 0, time.UTC)
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		res, err := jsonResponse(map[string]string{"gecko_says": "(V3) To the Moon!"})
+		res.Header.Set("Date", date.Format(http.TimeFormat))
+		res.Header.Set("Age", "12")
+		res.Header.Set("Cf-Cache-Status", "HIT")
+		res.Header.Set("X-Ratelimit-Remaining", "29")
+		res.Header.Set("X-Ratelimit-Reset", "60")
+		return res, err
+	})}
+	var meta *ResponseMeta
+	c := NewClient(WithHttpClient(hc), WithMiddleware(func(next DoFunc) DoFunc {
+		return func(call *Call) error {
+			err := next(call)
+			meta = call.Meta
+			return err
+		}
+	}))
+	_, ok := c.LastResponseMeta()
+	require.False(t, ok)
+
+	_, err := c.Ping()
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	require.Equal(t, http.StatusOK, meta.StatusCode)
+	require.True(t, date.Equal(meta.Date))
+	require.Equal(t, 12*time.Second, meta.Age)
+	require.Equal(t, "HIT", meta.CacheStatus)
+	require.Equal(t, 29, meta.RateLimitRemaining)
+	require.True(t, date.Add(time.Minute).Equal(meta.RateLimitReset))
+	require.Equal(t, "HIT", meta.Header.Get("Cf-Cache-Status"))
+
+	last, ok := c.LastResponseMeta()
+	require.True(t, ok)
+	require.Equal(t, *meta, last)
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	l := newRateLimiter(60)
+	now := time.Now()
+
+	// plenty left, the configured spacing holds
+	l.observe(1000, now.Add(time.Minute))
+	require.True(t, l.next.IsZero())
+
+	// 4 calls left for the next 40 seconds, spaced by 10 seconds
+	l.observe(4, now.Add(40*time.Second))
+	require.WithinDuration(t, now.Add(10*time.Second), l.next, time.Second)
+
+	// none left, wait for the reset
+	l.observe(0, now.Add(50*time.Second))
+	require.WithinDuration(t, now.Add(50*time.Second), l.next, time.Second)
+}
+
+func TestClient_RateLimitObserve(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var v interface{} = []string{"usd"}
+		if r.URL.Host == "api.geckoterminal.com" {
+			v = map[string]interface{}{"data": []interface{}{}}
+		}
+		res, err := jsonResponse(v)
+		res.Header.Set("X-Ratelimit-Remaining", "0")
+		res.Header.Set("X-Ratelimit-Reset", "60")
+		return res, err
+	})}
+	c := NewClient(WithHttpClient(hc), WithRateLimit(600))
+	// GeckoTerminal's quota is its own
+	_, err := c.Onchain().Networks(OnchainPageParams{})
+	require.NoError(t, err)
+	require.Less(t, time.Until(c.limiter.next), time.Second)
+	_, err = c.SimpleSupportedVsCurrencies()
+	require.NoError(t, err)
+	require.Greater(t, time.Until(c.limiter.next), 50*time.Second)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := newRateLimiter(1)
+	waited, err := l.wait(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, waited)
+
+	// the next slot is a minute away, the wait gives up with the context and hands the slot back
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	next := l.next
+	_, err = l.wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, next, l.next)
+}
+
+func TestClient_WithMeta(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		res, err := jsonResponse([]string{"usd"})
+		res.Header.Set("X-Ratelimit-Remaining", r.URL.Query().Get("n"))
+		return res, err
+	})}
+	c := NewClient(WithHttpClient(hc))
+	metas := make([]ResponseMeta, 10)
+	errs := make([]error, len(metas))
+	var wg sync.WaitGroup
+	for i := range metas {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var vscs []string
+			errs[i] = c.WithMeta(&metas[i]).Do(fmt.Sprintf("%s/simple/supported_vs_currencies?n=%d", c.BaseURL(), i), nil, &vscs)
+		}(i)
+	}
+	wg.Wait()
+	for i, m := range metas {
+		require.NoError(t, errs[i])
+		require.Equal(t, i, m.RateLimitRemaining)
+	}
+	last, ok := c.LastResponseMeta()
+	require.True(t, ok)
+	require.GreaterOrEqual(t, last.RateLimitRemaining, 0)
+}
+
+func TestClient_WithMetaBatch(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		prices := map[string]map[string]float64{}
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			prices[id] = map[string]float64{"usd": 1}
+		}
+		res, err := jsonResponse(prices)
+		res.Header.Set("X-Ratelimit-Remaining", "10")
+		return res, err
+	})}
+	c := NewClient(WithHttpClient(hc))
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, fmt.Sprintf("coin-%d", i))
+	}
+	var meta ResponseMeta
+	sps, err := c.WithMeta(&meta).SimplePriceBatch(SimplePriceBatchParams{
+		SimplePriceParams: SimplePriceParams{Ids: ids, VsCurrencies: []string{"usd"}},
+		MaxQueryLength:    100,
+		Concurrency:       8,
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(ids), len(sps.Prices))
+	require.Equal(t, 10, meta.RateLimitRemaining)
+}
@@ -0,0 +1,34 @@
+package gocko
+
+import (
+	"net/http"
+	"time"
+)
+
+// Call is one request going through the middlewares, they may alter Request before passing the call on.
+type Call struct {
+	Endpoint   string      // path template such as coins/{id}/ohlc
+	Params     QueryParams // nil for endpoints without parameters
+	Request    *http.Request
+	Result     interface{} // pointer the payload is decoded into
+	StatusCode int         // set once a response is received, 0 when there was none
+
+	RateLimitWait time.Duration // time spent blocked by WithRateLimit
+	Meta          *ResponseMeta // set once a response is received
+}
+
+// DoFunc performs a call, errors are StatusError for non 2xx responses, DecodeError for unexpected payloads
+// and transport errors otherwise.
+type DoFunc func(*Call) error
+
+// Middleware wraps the next DoFunc, eg. to time calls:
+//
+//	func(next gocko.DoFunc) gocko.DoFunc {
+//		return func(call *gocko.Call) error {
+//			start := time.Now()
+//			err := next(call)
+//			log.Println(call.Endpoint, call.StatusCode, time.Since(start), err)
+//			return err
+//		}
+//	}
+type Middleware func(next DoFunc) DoFunc
@@ -0,0 +1,72 @@
+package gocko
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "secret", r.Header.Get("X-Cg-Pro-Api-Key"))
+		switch r.URL.Path {
+		case "/api/v3/coins/bitcoin/ohlc":
+			return jsonResponse([][]float64{{1626912000000, 1, 2, 0.5, 1.5}})
+		case "/api/v3/coins/markets":
+			return jsonResponse(map[string]string{"error": "not a list"})
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"error":"coin not found"}`))}, nil
+		}
+	})}
+	var trace []string
+	var calls []Call
+	var errs []error
+	auth := func(next DoFunc) DoFunc {
+		return func(call *Call) error {
+			trace = append(trace, "auth")
+			call.Request.Header.Set("X-Cg-Pro-Api-Key", "secret")
+			return next(call)
+		}
+	}
+	record := func(next DoFunc) DoFunc {
+		return func(call *Call) error {
+			trace = append(trace, "record")
+			err := next(call)
+			calls, errs = append(calls, *call), append(errs, err)
+			return err
+		}
+	}
+	c := NewClient(WithHttpClient(hc), WithMiddleware(auth), WithMiddleware(record))
+
+	ohlc, err := c.CoinsOHLC(CoinsOHLCParams{Id: "bitcoin", VsCurrency: "usd", Days: Days1})
+	require.NoError(t, err)
+	require.Len(t, ohlc, 1)
+	require.Equal(t, []string{"auth", "record"}, trace)
+	require.Equal(t, "coins/{id}/ohlc", calls[0].Endpoint)
+	require.Equal(t, CoinsOHLCParams{Id: "bitcoin", VsCurrency: "usd", Days: Days1}, calls[0].Params)
+	require.Equal(t, http.StatusOK, calls[0].StatusCode)
+	require.NoError(t, errs[0])
+
+	_, err = c.CoinsMarkets(CoinsMarketsParams{VsCurrency: "usd"})
+	var de *DecodeError
+	require.True(t, errors.As(err, &de))
+	require.Equal(t, "coins/markets", calls[1].Endpoint)
+	require.Equal(t, http.StatusOK, calls[1].StatusCode)
+
+	_, err = c.CoinsID(CoinsDataParams{Id: "unknown"})
+	var se *StatusError
+	require.True(t, errors.As(err, &se))
+	require.Equal(t, http.StatusNotFound, calls[2].StatusCode)
+
+	var raw map[string]interface{}
+	require.Error(t, c.Do(c.BaseURL()+"/coins/unknown/tickers", nil, &raw))
+	require.Equal(t, "coins/unknown/tickers", calls[3].Endpoint)
+
+	// invalid params never reach the middlewares
+	_, err = c.CoinsOHLC(CoinsOHLCParams{})
+	require.Error(t, err)
+	require.Len(t, calls, 4)
+}
@@ -2,7 +2,9 @@ package gocko
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -11,9 +13,10 @@ type Ping struct {
 }
 
 type Coin struct {
-	Id     string `json:"id"`
-	Symbol string `json:"symbol"`
-	Name   string `json:"name"`
+	Id        string    `json:"id"`
+	Symbol    string    `json:"symbol"`
+	Name      string    `json:"name"`
+	Platforms Platforms `json:"platforms,omitempty"` // coins/list with include_platform only
 }
 
 type Image struct {
@@ -33,6 +36,14 @@ type Price struct {
 	MarketCap *float64
 	Vol24h    *float64
 	Change24h *float64
+	Exact     *ExactPrice // set with WithDecimalNumbers
+}
+
+type ExactPrice struct {
+	Price     Decimal
+	MarketCap *Decimal
+	Vol24h    *Decimal
+	Change24h *Decimal
 }
 
 type SimplePrice struct {
@@ -47,7 +58,7 @@ type SimplePrices struct {
 
 func (r *SimplePrices) UnmarshalJSON(bs []byte) error {
 	if len(r.vsCurrencies) == 0 {
-		return MissingParameterError
+		return &ParamError{Endpoint: "simple/price", Field: "VsCurrencies", Reason: MissingParameterError}
 	}
 	var data map[string]map[string]float64
 	err := json.Unmarshal(bs, &data)
@@ -78,6 +89,33 @@ func (r *SimplePrices) UnmarshalJSON(bs []byte) error {
 	return nil
 }
 
+func (r *SimplePrices) unmarshalExact(bs []byte) error {
+	var data map[string]map[string]Decimal
+	err := json.Unmarshal(bs, &data)
+	if err != nil {
+		return err
+	}
+	for k, v := range data {
+		for _, vsc := range r.vsCurrencies {
+			parser := func(k string) *Decimal {
+				if p, ok := v[fmt.Sprintf("%s_%s", vsc, k)]; ok {
+					return &p
+				}
+				return nil
+			}
+			price := r.Prices[k].CurrencyPrice[vsc]
+			price.Exact = &ExactPrice{
+				Price:     v[vsc],
+				MarketCap: parser("market_cap"),
+				Vol24h:    parser("24h_vol"),
+				Change24h: parser("24h_change"),
+			}
+			r.Prices[k].CurrencyPrice[vsc] = price
+		}
+	}
+	return nil
+}
+
 type StatusUpdate struct {
 	Description string    `json:"description"`
 	Category    string    `json:"category"`
@@ -88,6 +126,10 @@ type StatusUpdate struct {
 	Project     Project   `json:"project"`
 }
 
+type StatusUpdates struct {
+	StatusUpdates []StatusUpdate `json:"status_updates"`
+}
+
 type Description string
 type Platforms map[string]string
 type Project struct {
@@ -155,6 +197,18 @@ func (r *Platforms) UnmarshalJSON(bs []byte) error {
 	return nil
 }
 
+type CoinHistory struct {
+	Id         string `json:"id"`
+	Symbol     string `json:"symbol"`
+	Name       string `json:"name"`
+	Image      Image  `json:"image"`
+	MarketData *struct {
+		CurrentPrice map[string]float64 `json:"current_price"`
+		MarketCap    map[string]float64 `json:"market_cap"`
+		TotalVolume  map[string]float64 `json:"total_volume"`
+	} `json:"market_data"` // nil before the coin was listed
+}
+
 type ROI struct {
 	Times      float64 `json:"times"`
 	Currency   string  `json:"currency"`
@@ -194,16 +248,191 @@ type Market struct {
 	PriceChangePercentage30DInCurrency  *float64       `json:"price_change_percentage_30d_in_currency"`
 	PriceChangePercentage200DInCurrency *float64       `json:"price_change_percentage_200d_in_currency"`
 	PriceChangePercentage1YInCurrency   *float64       `json:"price_change_percentage_1y_in_currency"`
+	Exact                               *ExactMarket   `json:"-"` // set with WithDecimalNumbers
+}
+
+type ExactMarket struct {
+	CurrentPrice          Decimal  `json:"current_price"`
+	MarketCap             Decimal  `json:"market_cap"`
+	FullyDilutedValuation *Decimal `json:"fully_diluted_valuation"`
+	TotalVolume           Decimal  `json:"total_volume"`
+	High24H               Decimal  `json:"high_24h"`
+	Low24H                Decimal  `json:"low_24h"`
+	PriceChange24H        Decimal  `json:"price_change_24h"`
+	MarketCapChange24H    Decimal  `json:"market_cap_change_24h"`
+	CirculatingSupply     Decimal  `json:"circulating_supply"`
+	TotalSupply           *Decimal `json:"total_supply"`
+	MaxSupply             *Decimal `json:"max_supply"`
+	Ath                   Decimal  `json:"ath"`
+	Atl                   Decimal  `json:"atl"`
+}
+
+type markets []Market
+
+func (r *markets) unmarshalExact(bs []byte) error {
+	var data []ExactMarket
+	err := json.Unmarshal(bs, &data)
+	if err != nil {
+		return err
+	}
+	for i := range data {
+		if i < len(*r) {
+			(*r)[i].Exact = &data[i]
+		}
+	}
+	return nil
 }
 
 type Charts struct {
 	Prices       [][2]float64 `json:"prices"`
 	MarketCaps   [][2]float64 `json:"market_caps"`
 	TotalVolumes [][2]float64 `json:"total_volumes"`
+	Exact        *ExactCharts `json:"-"` // set with WithDecimalNumbers
+}
+
+type DecimalPoint struct {
+	Time  time.Time
+	Value Decimal
+}
+
+type ExactCharts struct {
+	Prices       []DecimalPoint
+	MarketCaps   []DecimalPoint
+	TotalVolumes []DecimalPoint
+}
+
+func (r *Charts) unmarshalExact(bs []byte) error {
+	var data struct {
+		Prices       [][2]Decimal `json:"prices"`
+		MarketCaps   [][2]Decimal `json:"market_caps"`
+		TotalVolumes [][2]Decimal `json:"total_volumes"`
+	}
+	err := json.Unmarshal(bs, &data)
+	if err != nil {
+		return err
+	}
+	r.Exact = &ExactCharts{
+		Prices:       decimalPoints(data.Prices),
+		MarketCaps:   decimalPoints(data.MarketCaps),
+		TotalVolumes: decimalPoints(data.TotalVolumes),
+	}
+	return nil
+}
+
+func decimalPoints(series [][2]Decimal) []DecimalPoint {
+	ps := make([]DecimalPoint, len(series))
+	for i, v := range series {
+		ps[i] = DecimalPoint{Time: msToTime(v[0].Float64()), Value: v[1]}
+	}
+	return ps
+}
+
+var MisalignedSeriesError = errors.New("misaligned series")
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+type ChartRow struct {
+	Time      time.Time
+	Price     float64
+	MarketCap float64
+	Volume    float64
+}
+
+func (c Charts) PricePoints() []Point     { return points(c.Prices) }
+func (c Charts) MarketCapPoints() []Point { return points(c.MarketCaps) }
+func (c Charts) VolumePoints() []Point    { return points(c.TotalVolumes) }
+
+// Rows joins the three series by timestamp, they are expected to share every timestamp in the same order.
+func (c Charts) Rows() ([]ChartRow, error) {
+	if len(c.MarketCaps) != len(c.Prices) || len(c.TotalVolumes) != len(c.Prices) {
+		return nil, fmt.Errorf("%w: %d prices, %d market caps, %d total volumes",
+			MisalignedSeriesError, len(c.Prices), len(c.MarketCaps), len(c.TotalVolumes))
+	}
+	rows := make([]ChartRow, len(c.Prices))
+	for i, p := range c.Prices {
+		if c.MarketCaps[i][0] != p[0] || c.TotalVolumes[i][0] != p[0] {
+			return nil, fmt.Errorf("%w: timestamps differ at index %d", MisalignedSeriesError, i)
+		}
+		rows[i] = ChartRow{
+			Time:      msToTime(p[0]),
+			Price:     p[1],
+			MarketCap: c.MarketCaps[i][1],
+			Volume:    c.TotalVolumes[i][1],
+		}
+	}
+	return rows, nil
+}
+
+func points(series [][2]float64) []Point {
+	ps := make([]Point, len(series))
+	for i, v := range series {
+		ps[i] = Point{Time: msToTime(v[0]), Value: v[1]}
+	}
+	return ps
+}
+
+func msToTime(ms float64) time.Time {
+	return time.UnixMilli(int64(ms)).UTC()
 }
 
 type OHLC [][5]float64
 
+var InvalidCandleError = errors.New("invalid candle")
+
+type Candle struct {
+	Time  time.Time
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+}
+
+type Candles []Candle
+
+// Candles converts the rows into candles. In strict mode the first inconsistent candle is reported as an error,
+// otherwise High and Low are widened to cover Open and Close, which absorbs CoinGecko's rounding artifacts.
+// Negative prices are no rounding artifact, they are reported in both modes.
+func (o OHLC) Candles(strict bool) (Candles, error) {
+	cs := make(Candles, len(o))
+	for i, v := range o {
+		c := Candle{Time: msToTime(v[0]), Open: v[1], High: v[2], Low: v[3], Close: v[4]}
+		if i > 0 && !c.Time.After(cs[i-1].Time) {
+			return nil, fmt.Errorf("%w: timestamp at index %d is not increasing", InvalidCandleError, i)
+		}
+		if !c.valid() && !strict {
+			c.High = math.Max(c.High, math.Max(c.Open, c.Close))
+			c.Low = math.Min(c.Low, math.Min(c.Open, c.Close))
+		}
+		if !c.valid() {
+			return nil, fmt.Errorf("%w: index %d %+v", InvalidCandleError, i, c)
+		}
+		cs[i] = c
+	}
+	return cs, nil
+}
+
+func (c Candle) valid() bool {
+	return c.High >= math.Max(c.Open, c.Close) && c.Low <= math.Min(c.Open, c.Close) && c.Low >= 0
+}
+
+// Interval infers the candle width as the most frequent gap between consecutive candles,
+// the latest candle is often still open and closer to its predecessor. It is 0 for less than two candles.
+func (cs Candles) Interval() time.Duration {
+	counts := map[time.Duration]int{}
+	var interval time.Duration
+	for i := 1; i < len(cs); i++ {
+		d := cs[i].Time.Sub(cs[i-1].Time)
+		counts[d]++
+		if counts[d] > counts[interval] || (counts[d] == counts[interval] && d > interval) {
+			interval = d
+		}
+	}
+	return interval
+}
+
 type ExchangeList []struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
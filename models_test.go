@@ -61,3 +61,92 @@ func unmarshalModel(filename string, ptr interface{}) error {
 	}
 	return json.Unmarshal(bs, ptr)
 }
+
+func TestCharts(t *testing.T) {
+	var ccs Charts
+	err := unmarshalModel("coins_market_chart", &ccs)
+	require.NoError(t, err)
+	ps := ccs.PricePoints()
+	require.Equal(t, 3, len(ps))
+	require.Equal(t, time.Date(2022, 3, 29, 0, 0, 0, 0, time.UTC), ps[0].Time)
+	require.Equal(t, 22.516862164558325, ps[0].Value)
+	rows, err := ccs.Rows()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(rows))
+	require.Equal(t, time.Date(2022, 3, 29, 2, 0, 0, 0, time.UTC), rows[2].Time)
+	require.Equal(t, 22.67316210358469, rows[2].Price)
+	require.Equal(t, 22381632510.23715, rows[2].MarketCap)
+	require.Equal(t, 1372120119.0716038, rows[2].Volume)
+
+	ccs.TotalVolumes[1][0]++
+	_, err = ccs.Rows()
+	require.ErrorIs(t, err, MisalignedSeriesError)
+	ccs.TotalVolumes = ccs.TotalVolumes[:2]
+	_, err = ccs.Rows()
+	require.ErrorIs(t, err, MisalignedSeriesError)
+}
+
+func TestCandles(t *testing.T) {
+	var ohlc OHLC
+	err := unmarshalModel("coins_ohlc", &ohlc)
+	require.NoError(t, err)
+	_, err = ohlc.Candles(true)
+	require.ErrorIs(t, err, InvalidCandleError)
+	cs, err := ohlc.Candles(false)
+	require.NoError(t, err)
+	require.Equal(t, 6, len(cs))
+	require.Equal(t, time.Date(2022, 3, 28, 0, 0, 0, 0, time.UTC), cs[0].Time)
+	require.Equal(t, Candle{Time: cs[5].Time, Open: 22.87, High: 22.94, Low: 22.70, Close: 22.94}, cs[5])
+	require.Equal(t, 4*time.Hour, cs.Interval())
+	require.Equal(t, time.Duration(0), cs[:1].Interval())
+
+	low := ohlc[3][3]
+	ohlc[3][3] = -1
+	_, err = ohlc.Candles(false)
+	require.ErrorIs(t, err, InvalidCandleError)
+	ohlc[3][3] = low
+
+	ohlc[2][0] = ohlc[1][0]
+	_, err = ohlc.Candles(false)
+	require.ErrorIs(t, err, InvalidCandleError)
+}
+
+func TestStatusUpdates(t *testing.T) {
+	var sus StatusUpdates
+	err := unmarshalModel("status_updates", &sus)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(sus.StatusUpdates))
+	su := sus.StatusUpdates[0]
+	require.Equal(t, "general", su.Category)
+	require.Equal(t, "polkadot", su.Project.Id)
+	require.Equal(t, time.Date(2021, 11, 11, 13, 47, 50, 581000000, time.UTC), su.CreatedAt)
+}
+
+func TestOnchainPools(t *testing.T) {
+	var ps Pools
+	err := unmarshalModel("onchain_pools", &ps)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ps.Data))
+	p := ps.Data[0]
+	require.Equal(t, "WETH / USDC 0.05%", p.Attributes.Name)
+	require.Equal(t, 3512.18262345, *p.Attributes.BaseTokenPriceUsd)
+	require.Nil(t, p.Attributes.MarketCapUsd)
+	require.Equal(t, 2.83, *p.Attributes.PriceChangePercentage.H24)
+	require.Equal(t, 4213, p.Attributes.Transactions.H24.Buys)
+	require.Nil(t, p.Attributes.Transactions.H24.Buyers)
+	require.Equal(t, "uniswap_v3", p.DexId())
+	require.Equal(t, "WETH", ps.Included.Tokens[p.BaseTokenId()].Attributes.Symbol)
+	require.Equal(t, "Uniswap V3", ps.Included.Dexes[p.DexId()].Attributes.Name)
+	_, ok := ps.Included.Tokens[p.QuoteTokenId()]
+	require.False(t, ok)
+}
+
+func TestOnchainOHLCV(t *testing.T) {
+	var ohlcv PoolOHLCV
+	err := unmarshalModel("onchain_ohlcv", &ohlcv)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(ohlcv.OHLCV))
+	require.Equal(t, float64(1712534400), ohlcv.OHLCV[0][0])
+	require.Equal(t, "WETH", ohlcv.Base.Symbol)
+	require.Equal(t, "usd-coin", *ohlcv.Quote.CoingeckoCoinId)
+}
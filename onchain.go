@@ -0,0 +1,169 @@
+package gocko
+
+import (
+	"fmt"
+)
+
+const onchainBaseURL = "https://api.geckoterminal.com/api/v2"
+
+// Onchain groups the GeckoTerminal (DEX) endpoints, their payloads follow JSON:API.
+type Onchain struct {
+	c *Client
+}
+
+func (c *Client) Onchain() *Onchain { return &Onchain{c: c} }
+
+//
+// Parameters
+//
+
+type OnchainPageParams struct {
+	Page int `query:"page,omitempty"`
+}
+
+func (p OnchainPageParams) Query() (map[string]string, error) {
+	return EncodeQuery("networks", p)
+}
+
+type OnchainDexesParams struct {
+	Network string `query:"network,required,path"`
+	Page    int    `query:"page,omitempty"`
+}
+
+func (p OnchainDexesParams) Query() (map[string]string, error) {
+	return EncodeQuery("networks/{network}/dexes", p)
+}
+
+type OnchainPoolsParams struct {
+	Network string   `query:"network,path"`          // required for TopPools, optional for TrendingPools and NewPools
+	Dex     string   `query:"dex,path"`              // TopPools only
+	Include []string `query:"include,omitempty,csv"` // base_token, quote_token, dex
+	Page    int      `query:"page,omitempty"`
+}
+
+func (p OnchainPoolsParams) Query() (map[string]string, error) {
+	v := validator{endpoint: "networks/{network}/pools"}
+	v.required("Network", len(p.Dex) == 0 || len(p.Network) > 0)
+	q, err := v.encode(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+type OnchainPoolParams struct {
+	Network string   `query:"network,required,path"`
+	Address string   `query:"address,required,path"`
+	Include []string `query:"include,omitempty,csv"` // base_token, quote_token, dex
+}
+
+func (p OnchainPoolParams) Query() (map[string]string, error) {
+	return EncodeQuery("networks/{network}/pools/{address}", p)
+}
+
+type OnchainTokenParams struct {
+	Network string   `query:"network,required,path"`
+	Address string   `query:"address,required,path"`
+	Include []string `query:"include,omitempty,csv"` // top_pools
+}
+
+func (p OnchainTokenParams) Query() (map[string]string, error) {
+	return EncodeQuery("networks/{network}/tokens/{address}", p)
+}
+
+type OnchainOHLCVParams struct {
+	Network         string `query:"network,required,path"`
+	PoolAddress     string `query:"pool_address,required,path"`
+	Timeframe       string `query:"timeframe,required,path"`    // day, hour, minute
+	Aggregate       int    `query:"aggregate,omitempty"`        // day: 1, hour: 1/4/12, minute: 1/5/15
+	BeforeTimestamp int64  `query:"before_timestamp,omitempty"` // unix seconds
+	Limit           int    `query:"limit,omitempty"`            // max 1000
+	Currency        string `query:"currency,omitempty"`         // usd, token
+	Token           string `query:"token,omitempty"`            // base, quote
+}
+
+func (p OnchainOHLCVParams) Query() (map[string]string, error) {
+	return EncodeQuery("networks/{network}/pools/{pool_address}/ohlcv/{timeframe}", p)
+}
+
+//
+// Requests
+//
+
+func (o *Onchain) Networks(p OnchainPageParams) ([]Network, error) {
+	var ns struct {
+		Data []Network `json:"data"`
+	}
+	err := o.c.do("networks", fmt.Sprintf("%s/networks", onchainBaseURL), p, &ns)
+	return ns.Data, err
+}
+
+func (o *Onchain) Dexes(p OnchainDexesParams) ([]Dex, error) {
+	var ds struct {
+		Data []Dex `json:"data"`
+	}
+	err := o.c.do("networks/{network}/dexes", fmt.Sprintf("%s/networks/%s/dexes", onchainBaseURL, p.Network), p, &ds)
+	return ds.Data, err
+}
+
+func (o *Onchain) TopPools(p OnchainPoolsParams) (Pools, error) {
+	var ps Pools
+	if len(p.Network) == 0 {
+		return ps, ParamErrors{{Endpoint: "networks/{network}/pools", Field: "Network", Reason: MissingParameterError}}
+	}
+	endpoint, url := "networks/{network}/pools", fmt.Sprintf("%s/networks/%s/pools", onchainBaseURL, p.Network)
+	if len(p.Dex) > 0 {
+		endpoint, url = "networks/{network}/dexes/{dex}/pools", fmt.Sprintf("%s/networks/%s/dexes/%s/pools", onchainBaseURL, p.Network, p.Dex)
+	}
+	err := o.c.do(endpoint, url, p, &ps)
+	return ps, err
+}
+
+func (o *Onchain) TrendingPools(p OnchainPoolsParams) (Pools, error) {
+	var ps Pools
+	err := o.c.do(networkEndpoint(p.Network, "trending_pools"), o.networkURL(p.Network, "trending_pools"), p, &ps)
+	return ps, err
+}
+
+func (o *Onchain) NewPools(p OnchainPoolsParams) (Pools, error) {
+	var ps Pools
+	err := o.c.do(networkEndpoint(p.Network, "new_pools"), o.networkURL(p.Network, "new_pools"), p, &ps)
+	return ps, err
+}
+
+func (o *Onchain) Pool(p OnchainPoolParams) (PoolData, error) {
+	var pd PoolData
+	err := o.c.do("networks/{network}/pools/{address}", fmt.Sprintf("%s/networks/%s/pools/%s", onchainBaseURL, p.Network, p.Address), p, &pd)
+	return pd, err
+}
+
+func (o *Onchain) Token(p OnchainTokenParams) (TokenData, error) {
+	var td TokenData
+	err := o.c.do("networks/{network}/tokens/{address}", fmt.Sprintf("%s/networks/%s/tokens/%s", onchainBaseURL, p.Network, p.Address), p, &td)
+	return td, err
+}
+
+func (o *Onchain) PoolOHLCV(p OnchainOHLCVParams) (PoolOHLCV, error) {
+	var ohlcv PoolOHLCV
+	err := o.c.do("networks/{network}/pools/{pool_address}/ohlcv/{timeframe}", fmt.Sprintf("%s/networks/%s/pools/%s/ohlcv/%s",
+		onchainBaseURL, p.Network, p.PoolAddress, p.Timeframe), p, &ohlcv)
+	return ohlcv, err
+}
+
+// networkURL scopes a pools listing to a network when one is given.
+func (o *Onchain) networkURL(network, path string) string {
+	if len(network) == 0 {
+		return fmt.Sprintf("%s/networks/%s", onchainBaseURL, path)
+	}
+	return fmt.Sprintf("%s/networks/%s/%s", onchainBaseURL, network, path)
+}
+
+func networkEndpoint(network, path string) string {
+	if len(network) == 0 {
+		return "networks/" + path
+	}
+	return "networks/{network}/" + path
+}
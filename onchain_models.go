@@ -0,0 +1,220 @@
+package gocko
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Resource struct {
+	Id            string                  `json:"id"`
+	Type          string                  `json:"type"`
+	Relationships map[string]Relationship `json:"relationships"`
+}
+
+type ResourceRef struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Relationship flattens the JSON:API `data` member, which is either a single reference or a list.
+type Relationship []ResourceRef
+
+func (r *Relationship) UnmarshalJSON(bs []byte) error {
+	var data struct {
+		Data json.RawMessage `json:"data"`
+	}
+	err := json.Unmarshal(bs, &data)
+	if err != nil {
+		return err
+	}
+	if len(data.Data) == 0 || string(data.Data) == "null" {
+		*r = nil
+		return nil
+	}
+	if data.Data[0] == '[' {
+		var refs []ResourceRef
+		err = json.Unmarshal(data.Data, &refs)
+		*r = refs
+		return err
+	}
+	var ref ResourceRef
+	err = json.Unmarshal(data.Data, &ref)
+	*r = Relationship{ref}
+	return err
+}
+
+// RelationId returns the id of the first resource referenced by the named relationship.
+func (r Resource) RelationId(name string) string {
+	if rel := r.Relationships[name]; len(rel) > 0 {
+		return rel[0].Id
+	}
+	return ""
+}
+
+type Network struct {
+	Resource
+	Attributes struct {
+		Name                     string  `json:"name"`
+		CoingeckoAssetPlatformId *string `json:"coingecko_asset_platform_id"`
+	} `json:"attributes"`
+}
+
+type Dex struct {
+	Resource
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+type Windows struct {
+	M5  *float64 `json:"m5,string"`
+	H1  *float64 `json:"h1,string"`
+	H6  *float64 `json:"h6,string"`
+	H24 *float64 `json:"h24,string"`
+}
+
+type Transactions struct {
+	Buys    int  `json:"buys"`
+	Sells   int  `json:"sells"`
+	Buyers  *int `json:"buyers"`
+	Sellers *int `json:"sellers"`
+}
+
+type PoolAttributes struct {
+	Name                          string    `json:"name"`
+	Address                       string    `json:"address"`
+	BaseTokenPriceUsd             *float64  `json:"base_token_price_usd,string"`
+	BaseTokenPriceNativeCurrency  *float64  `json:"base_token_price_native_currency,string"`
+	QuoteTokenPriceUsd            *float64  `json:"quote_token_price_usd,string"`
+	QuoteTokenPriceNativeCurrency *float64  `json:"quote_token_price_native_currency,string"`
+	PoolCreatedAt                 time.Time `json:"pool_created_at"`
+	FdvUsd                        *float64  `json:"fdv_usd,string"`
+	MarketCapUsd                  *float64  `json:"market_cap_usd,string"`
+	ReserveInUsd                  *float64  `json:"reserve_in_usd,string"`
+	PriceChangePercentage         Windows   `json:"price_change_percentage"`
+	VolumeUsd                     Windows   `json:"volume_usd"`
+	Transactions                  struct {
+		M5  Transactions `json:"m5"`
+		H1  Transactions `json:"h1"`
+		H24 Transactions `json:"h24"`
+	} `json:"transactions"`
+}
+
+type Pool struct {
+	Resource
+	Attributes PoolAttributes `json:"attributes"`
+}
+
+func (p Pool) BaseTokenId() string  { return p.RelationId("base_token") }
+func (p Pool) QuoteTokenId() string { return p.RelationId("quote_token") }
+func (p Pool) DexId() string        { return p.RelationId("dex") }
+
+type TokenAttributes struct {
+	Address           string   `json:"address"`
+	Name              string   `json:"name"`
+	Symbol            string   `json:"symbol"`
+	CoingeckoCoinId   *string  `json:"coingecko_coin_id"`
+	Decimals          int      `json:"decimals"`
+	TotalSupply       *float64 `json:"total_supply,string"`
+	PriceUsd          *float64 `json:"price_usd,string"`
+	FdvUsd            *float64 `json:"fdv_usd,string"`
+	TotalReserveInUsd *float64 `json:"total_reserve_in_usd,string"`
+	MarketCapUsd      *float64 `json:"market_cap_usd,string"`
+	VolumeUsd         Windows  `json:"volume_usd"`
+}
+
+type Token struct {
+	Resource
+	Attributes TokenAttributes `json:"attributes"`
+}
+
+// Included indexes the JSON:API `included` resources by their id.
+type Included struct {
+	Tokens map[string]Token
+	Dexes  map[string]Dex
+	Pools  map[string]Pool
+}
+
+func (r *Included) UnmarshalJSON(bs []byte) error {
+	var data []json.RawMessage
+	err := json.Unmarshal(bs, &data)
+	if err != nil {
+		return err
+	}
+	*r = Included{Tokens: map[string]Token{}, Dexes: map[string]Dex{}, Pools: map[string]Pool{}}
+	for _, raw := range data {
+		var ref ResourceRef
+		if err = json.Unmarshal(raw, &ref); err != nil {
+			return err
+		}
+		switch ref.Type {
+		case "token":
+			var t Token
+			err = json.Unmarshal(raw, &t)
+			r.Tokens[t.Id] = t
+		case "dex":
+			var d Dex
+			err = json.Unmarshal(raw, &d)
+			r.Dexes[d.Id] = d
+		case "pool":
+			var p Pool
+			err = json.Unmarshal(raw, &p)
+			r.Pools[p.Id] = p
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Pools struct {
+	Data     []Pool   `json:"data"`
+	Included Included `json:"included"`
+}
+
+type PoolData struct {
+	Data     Pool     `json:"data"`
+	Included Included `json:"included"`
+}
+
+type TokenData struct {
+	Data     Token    `json:"data"`
+	Included Included `json:"included"`
+}
+
+type PoolOHLCVToken struct {
+	Address         string  `json:"address"`
+	Name            string  `json:"name"`
+	Symbol          string  `json:"symbol"`
+	CoingeckoCoinId *string `json:"coingecko_coin_id"`
+}
+
+// PoolOHLCV rows are [timestamp(unix seconds), open, high, low, close, volume].
+type PoolOHLCV struct {
+	OHLCV [][6]float64
+	Base  PoolOHLCVToken
+	Quote PoolOHLCVToken
+}
+
+func (r *PoolOHLCV) UnmarshalJSON(bs []byte) error {
+	var data struct {
+		Data struct {
+			Attributes struct {
+				OHLCVList [][6]float64 `json:"ohlcv_list"`
+			} `json:"attributes"`
+		} `json:"data"`
+		Meta struct {
+			Base  PoolOHLCVToken `json:"base"`
+			Quote PoolOHLCVToken `json:"quote"`
+		} `json:"meta"`
+	}
+	err := json.Unmarshal(bs, &data)
+	if err != nil {
+		return err
+	}
+	r.OHLCV = data.Data.Attributes.OHLCVList
+	r.Base = data.Meta.Base
+	r.Quote = data.Meta.Quote
+	return nil
+}
@@ -2,95 +2,216 @@ package gocko
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var MissingParameterError = errors.New("missing parameter")
 var InvalidParameterError = errors.New("invalid parameter")
 
-type QueryParams interface {
-	toQuery() (map[string]string, error)
+// ParamError names the offending field, Reason is MissingParameterError or InvalidParameterError.
+type ParamError struct {
+	Endpoint string
+	Field    string
+	Reason   error
 }
 
-type CoinsParams struct {
-	includePlatform bool
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("%s: %s %s", e.Endpoint, e.Reason, e.Field)
 }
 
-func (c CoinsParams) toQuery() (map[string]string, error) {
-	return map[string]string{"include_platform": strconv.FormatBool(c.includePlatform)}, nil
+func (e *ParamError) Unwrap() error { return e.Reason }
+
+// ParamErrors collects every ParamError of a single request.
+type ParamErrors []*ParamError
+
+func (es ParamErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
 }
 
-type SimplePriceParams struct {
-	Ids                  []string // required
-	VsCurrencies         []string // required
-	IncludeMarketCap     bool
-	Include24hrVol       bool
-	Include24hrChange    bool
-	IncludeLastUpdatedAt bool
+func (es ParamErrors) Is(target error) bool {
+	for _, e := range es {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
 }
 
-func (p SimplePriceParams) toQuery() (map[string]string, error) {
-	if len(p.Ids) == 0 || len(p.VsCurrencies) == 0 {
-		return nil, MissingParameterError
+func (es ParamErrors) As(target interface{}) bool {
+	if pe, ok := target.(**ParamError); ok && len(es) > 0 {
+		*pe = es[0]
+		return true
 	}
-	return map[string]string{
-		"ids":                     strings.Join(p.Ids, ","),
-		"vs_currencies":           strings.Join(p.VsCurrencies, ","),
-		"include_market_cap":      strconv.FormatBool(p.IncludeMarketCap),
-		"include_24hr_vol":        strconv.FormatBool(p.Include24hrVol),
-		"include_24hr_change":     strconv.FormatBool(p.Include24hrChange),
-		"include_last_updated_at": strconv.FormatBool(p.IncludeLastUpdatedAt),
-	}, nil
+	return false
+}
 
+type validator struct {
+	endpoint string
+	errs     ParamErrors
 }
 
-type CoinsMarketsParams struct {
-	VsCurrency            string // required usd, eur, jpy, etc
-	Ids                   []string
-	Category              string // decentralized_finance_defi, stablecoins
-	Order                 string // gecko_desc, gecko_asc, market_cap_asc, market_cap_desc, volume_asc, volume_desc, id_asc, id_desc
-	PerPage               int    // max 250
-	Page                  int
-	PriceChangePercentage string // 1h, 24h, 7d, 14d, 30d, 200d, 1y (eg. '1h,24h,7d' comma-separated)
-	Sparkline             bool
-}
-
-func (c CoinsMarketsParams) toQuery() (map[string]string, error) {
-	if len(c.VsCurrency) == 0 {
-		return nil, MissingParameterError
+func (v *validator) required(field string, ok bool) {
+	if !ok {
+		v.errs = append(v.errs, &ParamError{Endpoint: v.endpoint, Field: field, Reason: MissingParameterError})
 	}
-	if c.Page < 0 || c.PerPage < 0 {
-		return nil, InvalidParameterError
-	}
-	q := map[string]string{}
-	q["vs_currency"] = c.VsCurrency
-	if len(c.Category) > 0 {
-		q["category"] = c.Category
+}
+
+func (v *validator) valid(field string, ok bool) {
+	if !ok {
+		v.errs = append(v.errs, &ParamError{Endpoint: v.endpoint, Field: field, Reason: InvalidParameterError})
 	}
-	if c.Ids != nil {
-		if len(c.Ids) > 0 {
-			q["ids"] = strings.Join(c.Ids, ",")
-		}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
 	}
-	if len(c.Order) > 0 {
-		q["order"] = c.Order
+	return v.errs
+}
+
+// QueryParams encodes the query parameters of a request, the error reports invalid or missing parameters
+// and is typically a ParamErrors. Implement it to call endpoints the library doesn't cover with Get.
+type QueryParams interface {
+	Query() (map[string]string, error)
+}
+
+// QueryValues passes parameters as they are.
+type QueryValues map[string]string
+
+func (v QueryValues) Query() (map[string]string, error) { return v, nil }
+
+type Order string
+
+const (
+	OrderGeckoDesc     Order = "gecko_desc"
+	OrderGeckoAsc      Order = "gecko_asc"
+	OrderMarketCapAsc  Order = "market_cap_asc"
+	OrderMarketCapDesc Order = "market_cap_desc"
+	OrderVolumeAsc     Order = "volume_asc"
+	OrderVolumeDesc    Order = "volume_desc"
+	OrderIdAsc         Order = "id_asc"
+	OrderIdDesc        Order = "id_desc"
+)
+
+func (o Order) valid() bool {
+	switch o {
+	case OrderGeckoDesc, OrderGeckoAsc, OrderMarketCapAsc, OrderMarketCapDesc,
+		OrderVolumeAsc, OrderVolumeDesc, OrderIdAsc, OrderIdDesc:
+		return true
 	}
-	if c.PerPage > 0 {
-		q["per_page"] = strconv.Itoa(c.PerPage)
+	return false
+}
+
+type PriceChangeWindow string
+
+const (
+	Window1h   PriceChangeWindow = "1h"
+	Window24h  PriceChangeWindow = "24h"
+	Window7d   PriceChangeWindow = "7d"
+	Window14d  PriceChangeWindow = "14d"
+	Window30d  PriceChangeWindow = "30d"
+	Window200d PriceChangeWindow = "200d"
+	Window1y   PriceChangeWindow = "1y"
+)
+
+func (w PriceChangeWindow) valid() bool {
+	switch w {
+	case Window1h, Window24h, Window7d, Window14d, Window30d, Window200d, Window1y:
+		return true
 	}
-	if c.Page > 0 {
-		q["page"] = strconv.Itoa(c.Page)
+	return false
+}
+
+// Days is either a positive number of days or DaysMax, the constants are the values accepted by every endpoint.
+type Days string
+
+const (
+	Days1   Days = "1"
+	Days7   Days = "7"
+	Days14  Days = "14"
+	Days30  Days = "30"
+	Days90  Days = "90"
+	Days180 Days = "180"
+	Days365 Days = "365"
+	DaysMax Days = "max"
+)
+
+func (d Days) valid() bool {
+	if d == DaysMax {
+		return true
 	}
-	if len(c.PriceChangePercentage) > 0 {
-		q["price_change_percentage"] = c.PriceChangePercentage
+	n, err := strconv.Atoi(string(d))
+	return err == nil && n > 0
+}
+
+// validOHLC reports whether d is one of the fixed ranges coins/{id}/ohlc accepts.
+func (d Days) validOHLC() bool {
+	switch d {
+	case Days1, Days7, Days14, Days30, Days90, Days180, Days365, DaysMax:
+		return true
 	}
-	q["sparkline"] = strconv.FormatBool(c.Sparkline)
-	return q, nil
+	return false
+}
+
+type CoinsParams struct {
+	IncludePlatform bool `query:"include_platform"`
+}
+
+func (c CoinsParams) Query() (map[string]string, error) {
+	return EncodeQuery("coins/list", c)
+}
+
+type SimplePriceParams struct {
+	Ids                  []string `query:"ids,required,csv"`
+	VsCurrencies         []string `query:"vs_currencies,required,csv"`
+	IncludeMarketCap     bool     `query:"include_market_cap"`
+	Include24hrVol       bool     `query:"include_24hr_vol"`
+	Include24hrChange    bool     `query:"include_24hr_change"`
+	IncludeLastUpdatedAt bool     `query:"include_last_updated_at"`
+}
+
+func (p SimplePriceParams) Query() (map[string]string, error) {
+	return EncodeQuery("simple/price", p)
+}
+
+type SimpleTokenPriceParams struct {
+	Id                   string   `query:"id,required,path"` // asset platform, eg. ethereum
+	ContractAddresses    []string `query:"contract_addresses,required,csv"`
+	VsCurrencies         []string `query:"vs_currencies,required,csv"`
+	IncludeMarketCap     bool     `query:"include_market_cap"`
+	Include24hrVol       bool     `query:"include_24hr_vol"`
+	Include24hrChange    bool     `query:"include_24hr_change"`
+	IncludeLastUpdatedAt bool     `query:"include_last_updated_at"`
+}
+
+func (p SimpleTokenPriceParams) Query() (map[string]string, error) {
+	return EncodeQuery("simple/token_price/{id}", p)
+}
+
+type CoinsMarketsParams struct {
+	VsCurrency            string              `query:"vs_currency,required"` // usd, eur, jpy, etc
+	Ids                   []string            `query:"ids,omitempty,csv"`
+	Category              string              `query:"category,omitempty"` // decentralized_finance_defi, stablecoins
+	Order                 Order               `query:"order,omitempty"`
+	PerPage               int                 `query:"per_page,omitempty"` // max 250
+	Page                  int                 `query:"page,omitempty"`
+	PriceChangePercentage []PriceChangeWindow `query:"price_change_percentage,omitempty,csv"`
+	Sparkline             bool                `query:"sparkline"`
+}
+
+func (c CoinsMarketsParams) Query() (map[string]string, error) {
+	return EncodeQuery("coins/markets", c)
 }
 
 type CoinsDataParams struct {
-	Id string
+	Id string `query:"id,required,path"`
 	//Localization  bool
 	//Tickers       bool
 	//MarketData    bool
@@ -99,60 +220,96 @@ type CoinsDataParams struct {
 	//Sparkline     bool
 }
 
-func (c CoinsDataParams) toQuery() (map[string]string, error) {
-	if len(c.Id) == 0 {
-		return nil, MissingParameterError
-	}
-	return map[string]string{
-		//"localization":   strconv.FormatBool(c.Localization),
-		//"tickers":        strconv.FormatBool(c.Tickers),
-		//"market_data":    strconv.FormatBool(c.MarketData),
-		//"community_data": strconv.FormatBool(c.CommunityData),
-		//"developer_data": strconv.FormatBool(c.DeveloperData),
-		//"sparkline":      strconv.FormatBool(c.Sparkline),
-	}, nil
+func (c CoinsDataParams) Query() (map[string]string, error) {
+	return EncodeQuery("coins/{id}", c)
+}
+
+type CoinsHistoryParams struct {
+	Id           string    `query:"id,required,path"`
+	Date         time.Time `query:"date,required,date"` // the snapshot is taken at 00:00 UTC of the day
+	Localization bool      `query:"localization"`
+}
+
+func (c CoinsHistoryParams) Query() (map[string]string, error) {
+	return EncodeQuery("coins/{id}/history", c)
+}
+
+type CoinsStatusUpdatesParams struct {
+	Id      string `query:"id,required,path"`
+	PerPage int    `query:"per_page,omitempty"`
+	Page    int    `query:"page,omitempty"`
+}
+
+func (c CoinsStatusUpdatesParams) Query() (map[string]string, error) {
+	return EncodeQuery("coins/{id}/status_updates", c)
 }
 
 type CoinsChartsParams struct {
-	Id         string // required
-	VsCurrency string // required
-	Days       string // required (eg. 1,14,30,max) 5min interval 1 day, 1h interval 1-90days, 1d interval 90+days
+	Id         string `query:"id,required,path"`
+	VsCurrency string `query:"vs_currency,required"`
+	Days       Days   `query:"days,required"` // 1,14,30,max... 5min interval 1 day, 1h interval 1-90days, 1d interval 90+days
+}
+
+func (c CoinsChartsParams) Query() (map[string]string, error) {
+	return EncodeQuery("coins/{id}/market_chart", c)
+}
+
+type CoinsChartsRangeParams struct {
+	Id         string    `query:"id,required,path"`
+	VsCurrency string    `query:"vs_currency,required"`
+	From       time.Time `query:"from,required,unix"` // 5min interval within 1 day, 1h interval 1-90days, 1d interval 90+days
+	To         time.Time `query:"to,required,unix"`
 }
 
-func (c CoinsChartsParams) toQuery() (map[string]string, error) {
-	if len(c.Id) == 0 || len(c.VsCurrency) == 0 || len(c.Days) == 0 {
-		return nil, MissingParameterError
+func (c CoinsChartsRangeParams) Query() (map[string]string, error) {
+	v := validator{endpoint: "coins/{id}/market_chart/range"}
+	q, err := v.encode(c)
+	if err != nil {
+		return nil, err
+	}
+	v.valid("To", c.From.IsZero() || c.To.IsZero() || c.To.After(c.From))
+	if err := v.err(); err != nil {
+		return nil, err
 	}
-	return map[string]string{
-		"vs_currency": c.VsCurrency,
-		"days":        c.Days,
-	}, nil
+	return q, nil
 }
 
 type CoinsOHLCParams struct {
-	Id         string // required
-	VsCurrency string // required
-	Days       string // required 1/7/14/30/90/180/365/max, intervals: 1-2d:30m, 3-30d:4h, 31+d:4d
+	Id         string `query:"id,required,path"`
+	VsCurrency string `query:"vs_currency,required"`
+	Days       Days   `query:"days,required"` // 1/7/14/30/90/180/365/max, intervals: 1-2d:30m, 3-30d:4h, 31+d:4d
 }
 
-func (c CoinsOHLCParams) toQuery() (map[string]string, error) {
-	if len(c.Id) == 0 || len(c.VsCurrency) == 0 || len(c.Days) == 0 {
-		return nil, MissingParameterError
+func (c CoinsOHLCParams) Query() (map[string]string, error) {
+	v := validator{endpoint: "coins/{id}/ohlc"}
+	q, err := v.encode(c)
+	if err != nil {
+		return nil, err
 	}
-	return map[string]string{
-		"vs_currency": c.VsCurrency,
-		"days":        c.Days,
-	}, nil
+	// numbers of days other than the fixed ranges pass the Days check but not this endpoint
+	v.valid("Days", len(c.Days) == 0 || !c.Days.valid() || c.Days.validOHLC())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return q, nil
 }
 
 type ExchangesParams struct {
-	PerPage int // max 250
-	Page    int
+	PerPage int `query:"per_page,omitempty"` // max 250
+	Page    int `query:"page,omitempty"`
 }
 
-func (e ExchangesParams) toQuery() (map[string]string, error) {
-	if e.PerPage < 0 || e.Page < 0 {
-		return nil, InvalidParameterError
-	}
-	return map[string]string{"per_page": strconv.Itoa(e.PerPage), "page": strconv.Itoa(e.Page)}, nil
+func (e ExchangesParams) Query() (map[string]string, error) {
+	return EncodeQuery("exchanges", e)
+}
+
+type StatusUpdatesParams struct {
+	Category    string `query:"category,omitempty"`     // general, milestone, partnership, exchange_listing, software_release, fund_movement, new_listings, event
+	ProjectType string `query:"project_type,omitempty"` // coin, market
+	PerPage     int    `query:"per_page,omitempty"`
+	Page        int    `query:"page,omitempty"`
+}
+
+func (s StatusUpdatesParams) Query() (map[string]string, error) {
+	return EncodeQuery("status_updates", s)
 }
@@ -0,0 +1,57 @@
+package gocko
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCoinsMarketsParams(t *testing.T) {
+	q, err := CoinsMarketsParams{
+		VsCurrency:            "usd",
+		Order:                 OrderMarketCapDesc,
+		PriceChangePercentage: []PriceChangeWindow{Window1h, Window24h, Window7d},
+	}.Query()
+	require.NoError(t, err)
+	require.Equal(t, "market_cap_desc", q["order"])
+	require.Equal(t, "1h,24h,7d", q["price_change_percentage"])
+
+	_, err = CoinsMarketsParams{VsCurrency: "usd", Order: "market_cap"}.Query()
+	require.ErrorIs(t, err, InvalidParameterError)
+	_, err = CoinsMarketsParams{VsCurrency: "usd", PriceChangePercentage: []PriceChangeWindow{"1d"}}.Query()
+	require.ErrorIs(t, err, InvalidParameterError)
+}
+
+func TestDaysParams(t *testing.T) {
+	for _, d := range []Days{Days1, "100", DaysMax} {
+		_, err := CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: d}.Query()
+		require.NoError(t, err)
+	}
+	for _, d := range []Days{"0", "-1", "1d", "Max"} {
+		_, err := CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: d}.Query()
+		require.ErrorIs(t, err, InvalidParameterError)
+	}
+	_, err := CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: Days365}.Query()
+	require.NoError(t, err)
+	_, err = CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: "100"}.Query()
+	require.ErrorIs(t, err, InvalidParameterError)
+}
+
+func TestParamErrors(t *testing.T) {
+	_, err := CoinsChartsParams{Id: "polkadot"}.Query()
+	require.ErrorIs(t, err, MissingParameterError)
+	require.NotErrorIs(t, err, InvalidParameterError)
+	var pes ParamErrors
+	require.ErrorAs(t, err, &pes)
+	require.Equal(t, 2, len(pes))
+	require.Equal(t, "VsCurrency", pes[0].Field)
+	require.Equal(t, "Days", pes[1].Field)
+	require.Equal(t, "coins/{id}/market_chart: missing parameter VsCurrency; coins/{id}/market_chart: missing parameter Days", err.Error())
+
+	_, err = CoinsMarketsParams{Page: -1, Order: "rank"}.Query()
+	require.ErrorIs(t, err, MissingParameterError)
+	require.ErrorIs(t, err, InvalidParameterError)
+	var pe *ParamError
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, "coins/markets", pe.Endpoint)
+	require.Equal(t, "VsCurrency", pe.Field)
+}
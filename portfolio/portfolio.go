@@ -0,0 +1,165 @@
+// Package portfolio values holdings of coins and tokens with CoinGecko prices.
+package portfolio
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/esenmx/gocko"
+)
+
+// Holding is either a coin, by CoinId, or a token, by Platform and Contract.
+type Holding struct {
+	CoinId    string  `json:"coin_id,omitempty"`
+	Platform  string  `json:"platform,omitempty"` // asset platform, eg. ethereum
+	Contract  string  `json:"contract,omitempty"`
+	Amount    float64 `json:"amount"`
+	CostBasis float64 `json:"cost_basis"` // total cost in the valuation currency
+}
+
+func (h Holding) token() bool { return len(h.CoinId) == 0 }
+
+type Position struct {
+	Holding
+	Price         float64
+	Value         float64
+	Allocation    float64  // percentage of the valuation total
+	UnrealizedPnL float64  // Value - CostBasis
+	Change24h     *float64 // value change in the last 24h, nil when unknown
+}
+
+type Valuation struct {
+	VsCurrency    string
+	Time          time.Time
+	Positions     []Position
+	Missing       []Holding // holdings without a price, excluded from the totals
+	Total         float64
+	CostBasis     float64
+	UnrealizedPnL float64
+	Change24h     float64 // value change of the positions having a 24h change
+}
+
+// Change24hPercentage relates Change24h to the value 24h ago.
+func (v Valuation) Change24hPercentage() float64 {
+	var change, prev float64
+	for _, p := range v.Positions {
+		if p.Change24h != nil {
+			change += *p.Change24h
+			prev += p.Value - *p.Change24h
+		}
+	}
+	if prev == 0 {
+		return 0
+	}
+	return change / prev * 100
+}
+
+// Value prices the holdings with simple/price and simple/token_price in vsCurrency.
+func Value(c *gocko.Client, holdings []Holding, vsCurrency string) (Valuation, error) {
+	var ids []string
+	contracts := map[string][]string{}
+	for _, h := range holdings {
+		if h.token() {
+			contracts[h.Platform] = append(contracts[h.Platform], h.Contract)
+		} else {
+			ids = append(ids, h.CoinId)
+		}
+	}
+	prices := map[string]gocko.Price{}
+	if len(ids) > 0 {
+		sps, err := c.SimplePriceBatch(gocko.SimplePriceBatchParams{SimplePriceParams: gocko.SimplePriceParams{
+			Ids: ids, VsCurrencies: []string{vsCurrency}, Include24hrChange: true,
+		}})
+		if err != nil {
+			return Valuation{}, err
+		}
+		for id, sp := range sps.Prices {
+			prices[id] = sp.CurrencyPrice[vsCurrency]
+		}
+	}
+	for platform, addresses := range contracts {
+		sps, err := c.SimpleTokenPriceBatch(gocko.SimpleTokenPriceBatchParams{SimpleTokenPriceParams: gocko.SimpleTokenPriceParams{
+			Id: platform, ContractAddresses: addresses, VsCurrencies: []string{vsCurrency}, Include24hrChange: true,
+		}})
+		if err != nil {
+			return Valuation{}, err
+		}
+		for address, sp := range sps.Prices {
+			prices[tokenKey(platform, address)] = sp.CurrencyPrice[vsCurrency]
+		}
+	}
+	return valuate(holdings, vsCurrency, time.Now(), func(h Holding) (gocko.Price, bool) {
+		key := h.CoinId
+		if h.token() {
+			key = tokenKey(h.Platform, h.Contract)
+		}
+		p, ok := prices[key]
+		return p, ok
+	}), nil
+}
+
+// ValueAt prices the coin holdings with coins/{id}/history at 00:00 UTC of date. Tokens are reported as Missing,
+// the history endpoint only knows coin ids, and positions have no 24h change.
+func ValueAt(c *gocko.Client, holdings []Holding, vsCurrency string, date time.Time) (Valuation, error) {
+	prices := map[string]gocko.Price{}
+	for _, h := range holdings {
+		if _, ok := prices[h.CoinId]; h.token() || ok {
+			continue
+		}
+		ch, err := c.CoinsHistory(gocko.CoinsHistoryParams{Id: h.CoinId, Date: date})
+		var se *gocko.StatusError
+		if errors.As(err, &se) && se.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return Valuation{}, err
+		}
+		if ch.MarketData == nil {
+			continue
+		}
+		if p, ok := ch.MarketData.CurrentPrice[vsCurrency]; ok {
+			prices[h.CoinId] = gocko.Price{Price: p}
+		}
+	}
+	d := date.UTC()
+	return valuate(holdings, vsCurrency, time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC),
+		func(h Holding) (gocko.Price, bool) {
+			p, ok := prices[h.CoinId]
+			return p, ok && !h.token()
+		}), nil
+}
+
+func valuate(holdings []Holding, vsCurrency string, t time.Time, price func(Holding) (gocko.Price, bool)) Valuation {
+	v := Valuation{VsCurrency: vsCurrency, Time: t}
+	for _, h := range holdings {
+		p, ok := price(h)
+		if !ok {
+			v.Missing = append(v.Missing, h)
+			continue
+		}
+		pos := Position{Holding: h, Price: p.Price, Value: h.Amount * p.Price}
+		pos.UnrealizedPnL = pos.Value - h.CostBasis
+		if p.Change24h != nil {
+			// the price 24h ago is Price / (1 + change%)
+			change := pos.Value - pos.Value/(1+*p.Change24h/100)
+			pos.Change24h = &change
+			v.Change24h += change
+		}
+		v.Total += pos.Value
+		v.CostBasis += h.CostBasis
+		v.UnrealizedPnL += pos.UnrealizedPnL
+		v.Positions = append(v.Positions, pos)
+	}
+	for i := range v.Positions {
+		if v.Total != 0 {
+			v.Positions[i].Allocation = v.Positions[i].Value / v.Total * 100
+		}
+	}
+	return v
+}
+
+func tokenKey(platform, address string) string {
+	return platform + "/" + strings.ToLower(address)
+}
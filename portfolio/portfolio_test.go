@@ -0,0 +1,91 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/esenmx/gocko"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func client(t *testing.T) *gocko.Client {
+	return gocko.NewClient(gocko.WithHttpClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var body interface{}
+		switch r.URL.Path {
+		case "/api/v3/simple/price":
+			require.Equal(t, "true", r.URL.Query().Get("include_24hr_change"))
+			body = map[string]map[string]float64{
+				"ethereum": {"usd": 2000, "usd_24h_change": 25},
+				"polkadot": {"usd": 10},
+			}
+		case "/api/v3/simple/token_price/ethereum":
+			require.Equal(t, "0x1F9840A85D5AF5B0FE9D5BF1D1D45C4EEA3A6E22", r.URL.Query().Get("contract_addresses"))
+			body = map[string]map[string]float64{
+				"0x1f9840a85d5af5b0fe9d5bf1d1d45c4eea3a6e22": {"usd": 5, "usd_24h_change": -50},
+			}
+		case "/api/v3/coins/ethereum/history":
+			require.Equal(t, "01-01-2021", r.URL.Query().Get("date"))
+			body = map[string]interface{}{"id": "ethereum", "market_data": map[string]interface{}{
+				"current_price": map[string]float64{"usd": 730},
+			}}
+		case "/api/v3/coins/polkadot/history":
+			body = map[string]interface{}{"id": "polkadot"}
+		case "/api/v3/coins/unknown/history":
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"error":"coin not found"}`))}, nil
+		default:
+			return nil, fmt.Errorf("unexpected request %s", r.URL)
+		}
+		bs, _ := json.Marshal(body)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(bs))}, nil
+	})}))
+}
+
+var holdings = []Holding{
+	{CoinId: "ethereum", Amount: 2, CostBasis: 3000},
+	{CoinId: "polkadot", Amount: 100, CostBasis: 2000},
+	{Platform: "ethereum", Contract: "0x1F9840A85D5AF5B0FE9D5BF1D1D45C4EEA3A6E22", Amount: 200, CostBasis: 500},
+	{CoinId: "unknown", Amount: 1},
+}
+
+func TestValue(t *testing.T) {
+	v, err := Value(client(t), holdings, "usd")
+	require.NoError(t, err)
+	require.Equal(t, 3, len(v.Positions))
+	require.Equal(t, []Holding{holdings[3]}, v.Missing)
+	require.Equal(t, 6000.0, v.Total)
+	require.Equal(t, 5500.0, v.CostBasis)
+	require.Equal(t, 500.0, v.UnrealizedPnL)
+	eth, dot, uni := v.Positions[0], v.Positions[1], v.Positions[2]
+	require.Equal(t, 4000.0, eth.Value)
+	require.InDelta(t, 66.666, eth.Allocation, 1e-3)
+	require.Equal(t, 1000.0, eth.UnrealizedPnL)
+	require.Equal(t, 800.0, *eth.Change24h)
+	require.Nil(t, dot.Change24h)
+	require.Equal(t, -1000.0, dot.UnrealizedPnL)
+	require.Equal(t, 1000.0, uni.Value)
+	require.Equal(t, -1000.0, *uni.Change24h)
+	require.Equal(t, -200.0, v.Change24h)
+	// 5000 now, 5200 24h ago
+	require.InDelta(t, -3.846, v.Change24hPercentage(), 1e-3)
+}
+
+func TestValueAt(t *testing.T) {
+	v, err := ValueAt(client(t), holdings, "usd", time.Date(2021, 1, 1, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), v.Time)
+	require.Equal(t, 1, len(v.Positions))
+	require.Equal(t, 3, len(v.Missing))
+	require.Equal(t, 1460.0, v.Total)
+	require.Equal(t, 100.0, v.Positions[0].Allocation)
+	require.Equal(t, -1540.0, v.UnrealizedPnL)
+	require.Nil(t, v.Positions[0].Change24h)
+}
@@ -0,0 +1,174 @@
+// Package proxy serves the CoinGecko /api/v3 routes from a cache, forwarding misses through one shared gocko.Client.
+// Point the clients of a whole team at it with gocko.WithBaseURL("http://proxy:8080/api/v3").
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/esenmx/gocko"
+)
+
+const prefix = "/api/v3"
+
+// Route sets the TTL of the paths matching Pattern, a path.Match pattern such as /api/v3/coins/*/ohlc.
+type Route struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// DefaultRoutes follow how often CoinGecko refreshes each kind of data.
+var DefaultRoutes = []Route{
+	{"/api/v3/ping", 10 * time.Second},
+	{"/api/v3/simple/price", 30 * time.Second},
+	{"/api/v3/simple/token_price/*", 30 * time.Second},
+	{"/api/v3/simple/supported_vs_currencies", time.Hour},
+	{"/api/v3/coins/list", time.Hour},
+	{"/api/v3/coins/markets", time.Minute},
+	{"/api/v3/coins/*/history", 24 * time.Hour},
+	{"/api/v3/coins/*/market_chart", 5 * time.Minute},
+	{"/api/v3/coins/*/market_chart/range", 5 * time.Minute},
+	{"/api/v3/coins/*/ohlc", 15 * time.Minute},
+	{"/api/v3/coins/*", 5 * time.Minute},
+	{"/api/v3/exchanges/list", time.Hour},
+}
+
+type Options struct {
+	Routes     []Route       // first match wins, defaults to DefaultRoutes
+	DefaultTTL time.Duration // for paths matching no route, defaults to 1 minute
+	MaxEntries int           // defaults to 10000
+}
+
+// Proxy is an http.Handler, responses carry X-Cache: HIT, MISS or SHARED when a concurrent identical miss was joined.
+type Proxy struct {
+	c    *gocko.Client
+	opts Options
+	now  func() time.Time
+
+	mu       sync.Mutex
+	cache    map[string]entry
+	inflight map[string]*call
+}
+
+type entry struct {
+	body    []byte
+	expires time.Time
+}
+
+type call struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// New forwards through c, which should be rate limited and must not point at the proxy itself.
+func New(c *gocko.Client, opts Options) *Proxy {
+	if opts.Routes == nil {
+		opts.Routes = DefaultRoutes
+	}
+	if opts.DefaultTTL <= 0 {
+		opts.DefaultTTL = time.Minute
+	}
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 10000
+	}
+	return &Proxy{c: c, opts: opts, now: time.Now, cache: map[string]entry{}, inflight: map[string]*call{}}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, prefix+"/") {
+		http.NotFound(w, r)
+		return
+	}
+	// Encode sorts the parameters, so their order doesn't split the cache
+	key := r.URL.Path
+	if q := r.URL.Query().Encode(); len(q) > 0 {
+		key += "?" + q
+	}
+
+	p.mu.Lock()
+	if e, ok := p.cache[key]; ok && p.now().Before(e.expires) {
+		p.mu.Unlock()
+		write(w, "HIT", e.body, nil)
+		return
+	}
+	if c, ok := p.inflight[key]; ok {
+		p.mu.Unlock()
+		// a client hanging up stops waiting, the call goes on for the others
+		select {
+		case <-c.done:
+			write(w, "SHARED", c.body, c.err)
+		case <-r.Context().Done():
+		}
+		return
+	}
+	c := &call{done: make(chan struct{})}
+	p.inflight[key] = c
+	p.mu.Unlock()
+
+	var raw json.RawMessage
+	c.err = p.c.Do(p.c.BaseURL()+strings.TrimPrefix(key, prefix), nil, &raw)
+	c.body = raw
+
+	p.mu.Lock()
+	delete(p.inflight, key)
+	if c.err == nil {
+		p.store(key, entry{body: c.body, expires: p.now().Add(p.ttl(r.URL.Path))})
+	}
+	p.mu.Unlock()
+	close(c.done)
+	write(w, "MISS", c.body, c.err)
+}
+
+func (p *Proxy) ttl(urlPath string) time.Duration {
+	for _, route := range p.opts.Routes {
+		if ok, _ := path.Match(route.Pattern, urlPath); ok {
+			return route.TTL
+		}
+	}
+	return p.opts.DefaultTTL
+}
+
+// store must be called with mu held, when full the expired entries are dropped first, then arbitrary ones.
+func (p *Proxy) store(key string, e entry) {
+	if len(p.cache) >= p.opts.MaxEntries {
+		now := p.now()
+		for k, v := range p.cache {
+			if !now.Before(v.expires) {
+				delete(p.cache, k)
+			}
+		}
+		for k := range p.cache {
+			if len(p.cache) < p.opts.MaxEntries {
+				break
+			}
+			delete(p.cache, k)
+		}
+	}
+	p.cache[key] = e
+}
+
+// write passes upstream errors through with their status, they are never cached.
+func write(w http.ResponseWriter, cache string, body []byte, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Cache", cache)
+	var se *gocko.StatusError
+	switch {
+	case errors.As(err, &se):
+		w.WriteHeader(se.StatusCode)
+		w.Write(se.Body)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	default:
+		w.Write(body)
+	}
+}
@@ -0,0 +1,153 @@
+package proxy
+
+import (
+	"context"
+	"github.com/esenmx/gocko"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestProxy(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c := gocko.NewClient(gocko.WithHttpClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		switch r.URL.Path {
+		case "/api/v3/simple/price":
+			<-release
+			require.Equal(t, "ids=bitcoin&vs_currencies=usd", r.URL.RawQuery)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"bitcoin":{"usd":1}}`))}, nil
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"error":"coin not found"}`))}, nil
+		}
+	})}))
+	p := New(c, Options{})
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	var wg sync.WaitGroup
+	recs := make([]*httptest.ResponseRecorder, 5)
+	for i := range recs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recs[i] = get("/api/v3/simple/price?vs_currencies=usd&ids=bitcoin")
+		}(i)
+	}
+	for {
+		p.mu.Lock()
+		n := len(p.inflight)
+		p.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// let the waiters join the call in flight
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	headers := map[string]int{}
+	for _, rec := range recs {
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"bitcoin":{"usd":1}}`, rec.Body.String())
+		headers[rec.Header().Get("X-Cache")]++
+	}
+	require.Equal(t, 1, headers["MISS"])
+
+	rec := get("/api/v3/simple/price?ids=bitcoin&vs_currencies=usd")
+	require.Equal(t, "HIT", rec.Header().Get("X-Cache"))
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	now = now.Add(31 * time.Second)
+	rec = get("/api/v3/simple/price?ids=bitcoin&vs_currencies=usd")
+	require.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+	for i := 0; i < 2; i++ {
+		rec = get("/api/v3/coins/unknown")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+		require.JSONEq(t, `{"error":"coin not found"}`, rec.Body.String())
+	}
+	require.EqualValues(t, 4, atomic.LoadInt32(&calls))
+
+	require.Equal(t, http.StatusNotFound, get("/metrics").Code)
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v3/ping", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestProxy_TTL(t *testing.T) {
+	p := New(nil, Options{DefaultTTL: 2 * time.Minute})
+	require.Equal(t, 10*time.Second, p.ttl("/api/v3/ping"))
+	require.Equal(t, 5*time.Minute, p.ttl("/api/v3/coins/bitcoin"))
+	require.Equal(t, 15*time.Minute, p.ttl("/api/v3/coins/bitcoin/ohlc"))
+	require.Equal(t, 5*time.Minute, p.ttl("/api/v3/coins/bitcoin/market_chart/range"))
+	require.Equal(t, time.Hour, p.ttl("/api/v3/coins/list"))
+	require.Equal(t, 2*time.Minute, p.ttl("/api/v3/exchanges/binance"))
+}
+
+func TestProxy_Client(t *testing.T) {
+	upstream := gocko.NewClient(gocko.WithHttpClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/v3/ping", r.URL.Path)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"gecko_says":"(V3) To the Moon!"}`))}, nil
+	})}))
+	srv := httptest.NewServer(New(upstream, Options{}))
+	defer srv.Close()
+
+	c := gocko.NewClient(gocko.WithBaseURL(srv.URL + "/api/v3"))
+	ping, err := c.Ping()
+	require.NoError(t, err)
+	require.Equal(t, "(V3) To the Moon!", ping.GeckoSays)
+}
+
+func TestProxy_WaiterHangsUp(t *testing.T) {
+	release := make(chan struct{})
+	c := gocko.NewClient(gocko.WithHttpClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})}))
+	p := New(c, Options{})
+	leader := make(chan struct{})
+	go func() {
+		defer close(leader)
+		p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil))
+	}()
+	for {
+		p.mu.Lock()
+		n := len(p.inflight)
+		p.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil).WithContext(ctx))
+	require.Empty(t, rec.Header().Get("X-Cache"))
+
+	close(release)
+	<-leader
+}
@@ -0,0 +1,163 @@
+package gocko
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnsupportedQueryError is returned by EncodeQuery for values it can't encode, wrapped with the reason.
+var UnsupportedQueryError = errors.New("unsupported query parameters")
+
+// EncodeQuery encodes the exported fields of the struct p, or pointer to it, tagged `query:"name,options"`,
+// reporting invalid values as ParamErrors of endpoint. It lets custom params types implement QueryParams:
+//
+//	func (p TickersParams) Query() (map[string]string, error) { return gocko.EncodeQuery("coins/{id}/tickers", p) }
+//
+// Strings, bools, non negative integers, time.Time and slices of these are supported, the options are:
+//
+//	required   missing when empty
+//	omitempty  not sent when empty
+//	csv        joins a slice with commas
+//	path       part of the URL path, only validated
+//	unix       time.Time as unix seconds
+//	date       time.Time as dd-mm-yyyy in UTC
+//
+// Enums such as Order, Days and PriceChangeWindow are checked against their accepted values. Other types,
+// slices without csv and unknown options are reported as UnsupportedQueryError.
+func EncodeQuery(endpoint string, p interface{}) (map[string]string, error) {
+	v := validator{endpoint: endpoint}
+	q, err := v.encode(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// enum is implemented by the parameter types having a fixed set of values.
+type enum interface {
+	valid() bool
+}
+
+type queryTag struct {
+	name                                       string
+	required, omitempty, csv, path, unix, date bool
+}
+
+func parseQueryTag(tag string) (queryTag, error) {
+	parts := strings.Split(tag, ",")
+	t := queryTag{name: parts[0]}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "required":
+			t.required = true
+		case "omitempty":
+			t.omitempty = true
+		case "csv":
+			t.csv = true
+		case "path":
+			t.path = true
+		case "unix":
+			t.unix = true
+		case "date":
+			t.date = true
+		default:
+			return t, fmt.Errorf("%w: unknown tag option %q", UnsupportedQueryError, opt)
+		}
+	}
+	return t, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// encode records the invalid fields of p in v, the returned query is meaningless when there are some.
+// The error reports what can't be encoded at all.
+func (v *validator) encode(p interface{}) (map[string]string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(p))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T isn't a struct", UnsupportedQueryError, p)
+	}
+	q := map[string]string{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, ok := f.Tag.Lookup("query")
+		if !ok || tag == "-" || len(f.PkgPath) > 0 {
+			continue
+		}
+		t, err := parseQueryTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%w of field %s", err, f.Name)
+		}
+		fv := rv.Field(i)
+		empty := isEmpty(fv)
+		if t.required {
+			v.required(f.Name, !empty)
+		}
+		if empty && (t.required || t.omitempty || t.path) {
+			continue
+		}
+		var s string
+		if fv.Kind() == reflect.Slice {
+			if !t.csv {
+				return nil, fmt.Errorf("%w: field %s is a slice without the csv option", UnsupportedQueryError, f.Name)
+			}
+			elems := make([]string, fv.Len())
+			for j := range elems {
+				if elems[j], err = v.value(fmt.Sprintf("%s[%d]", f.Name, j), fv.Index(j), t); err != nil {
+					return nil, err
+				}
+			}
+			s = strings.Join(elems, ",")
+		} else if s, err = v.value(f.Name, fv, t); err != nil {
+			return nil, err
+		}
+		if !t.path {
+			q[t.name] = s
+		}
+	}
+	return q, nil
+}
+
+func (v *validator) value(field string, fv reflect.Value, t queryTag) (string, error) {
+	if e, ok := fv.Interface().(enum); ok {
+		v.valid(field, e.valid())
+	}
+	if fv.Type() == timeType {
+		tm := fv.Interface().(time.Time)
+		switch {
+		case t.unix:
+			return strconv.FormatInt(tm.Unix(), 10), nil
+		case t.date:
+			return tm.UTC().Format("02-01-2006"), nil
+		default:
+			return tm.Format(time.RFC3339), nil
+		}
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.valid(field, fv.Int() >= 0)
+		return strconv.FormatInt(fv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("%w: field %s of type %s", UnsupportedQueryError, field, fv.Type())
+}
+
+func isEmpty(fv reflect.Value) bool {
+	switch fv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return fv.Len() == 0
+	}
+	return fv.IsZero()
+}
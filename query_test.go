@@ -0,0 +1,99 @@
+package gocko
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	q, err := ExchangesParams{}.Query()
+	require.NoError(t, err)
+	require.Empty(t, q)
+
+	q, err = CoinsParams{IncludePlatform: true}.Query()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"include_platform": "true"}, q)
+
+	q, err = SimplePriceParams{Ids: []string{"bitcoin", "ethereum"}, VsCurrencies: []string{"usd"}, Include24hrVol: true}.Query()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"ids":                     "bitcoin,ethereum",
+		"vs_currencies":           "usd",
+		"include_market_cap":      "false",
+		"include_24hr_vol":        "true",
+		"include_24hr_change":     "false",
+		"include_last_updated_at": "false",
+	}, q)
+
+	q, err = CoinsMarketsParams{VsCurrency: "usd", Ids: []string{}, PerPage: 10}.Query()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"vs_currency": "usd", "per_page": "10", "sparkline": "false"}, q)
+
+	date := time.Date(2021, 7, 22, 23, 0, 0, 0, time.FixedZone("", -2*3600))
+	q, err = CoinsHistoryParams{Id: "bitcoin", Date: date}.Query()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"date": "23-07-2021", "localization": "false"}, q)
+
+	q, err = CoinsChartsRangeParams{Id: "bitcoin", VsCurrency: "usd", From: time.Unix(1626912000, 0), To: time.Unix(1626998400, 0)}.Query()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"vs_currency": "usd", "from": "1626912000", "to": "1626998400"}, q)
+	_, err = CoinsChartsRangeParams{Id: "bitcoin", VsCurrency: "usd", From: time.Unix(1626998400, 0), To: time.Unix(1626912000, 0)}.Query()
+	require.ErrorIs(t, err, InvalidParameterError)
+
+	_, err = OnchainOHLCVParams{Network: "eth", PoolAddress: "0x", Timeframe: "day", Limit: -1}.Query()
+	var pe *ParamError
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, "Limit", pe.Field)
+	require.ErrorIs(t, pe, InvalidParameterError)
+
+	_, err = OnchainPoolsParams{Dex: "uniswap_v3"}.Query()
+	require.ErrorIs(t, err, MissingParameterError)
+}
+
+func TestEncodeQuery_Custom(t *testing.T) {
+	type tickersParams struct {
+		Id          string   `query:"id,required,path"`
+		ExchangeIds []string `query:"exchange_ids,omitempty,csv"`
+		Page        int      `query:"page,omitempty"`
+		Order       Order    `query:"order,omitempty"`
+		Internal    string
+	}
+	q, err := EncodeQuery("coins/{id}/tickers", tickersParams{Id: "bitcoin", ExchangeIds: []string{"binance"}, Internal: "x"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"exchange_ids": "binance"}, q)
+
+	_, err = EncodeQuery("coins/{id}/tickers", tickersParams{Page: -1, Order: "rank"})
+	var pes ParamErrors
+	require.ErrorAs(t, err, &pes)
+	require.Equal(t, "coins/{id}/tickers: missing parameter Id; coins/{id}/tickers: invalid parameter Page; "+
+		"coins/{id}/tickers: invalid parameter Order", err.Error())
+
+	q, err = EncodeQuery("coins/{id}/tickers", &tickersParams{Id: "bitcoin", Page: 2})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"page": "2"}, q)
+
+	q, err = EncodeQuery("coins/{id}/tickers", struct {
+		Page  int    `query:"page"`
+		token string `query:"token"`
+	}{Page: 1, token: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"page": "1"}, q)
+
+	for _, p := range []interface{}{
+		(*tickersParams)(nil),
+		"bitcoin",
+		struct {
+			Ids []string `query:"ids"`
+		}{Ids: []string{"bitcoin"}},
+		struct {
+			Price float64 `query:"price"`
+		}{},
+		struct {
+			Page int `query:"page,sorted"`
+		}{},
+	} {
+		_, err = EncodeQuery("coins/{id}/tickers", p)
+		require.ErrorIs(t, err, UnsupportedQueryError)
+	}
+}
@@ -0,0 +1,70 @@
+package gocko
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces calls evenly, the public API allows around 10-50 calls per minute depending on load.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(callsPerMinute int) *rateLimiter {
+	return &rateLimiter{interval: time.Minute / time.Duration(callsPerMinute)}
+}
+
+// wait blocks until the caller may issue the next call and returns how long it blocked. When ctx is done first
+// it returns ctx.Err(), giving the slot back unless a later caller already reserved the next one.
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	slot := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+	d := slot.Sub(now)
+	if d <= 0 {
+		return 0, nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return d, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.next.Equal(slot.Add(l.interval)) {
+			l.next = slot
+		}
+		l.mu.Unlock()
+		return time.Since(now), ctx.Err()
+	}
+}
+
+// observe slows down to spread the remaining calls reported by the server until reset,
+// which is assumed a minute away when unknown. No call is issued before reset once none remain.
+func (l *rateLimiter) observe(remaining int, reset time.Time) {
+	now := time.Now()
+	if reset.Before(now) {
+		reset = now.Add(time.Minute)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if remaining <= 0 {
+		if l.next.Before(reset) {
+			l.next = reset
+		}
+		return
+	}
+	if spacing := reset.Sub(now) / time.Duration(remaining); spacing > l.interval {
+		if next := now.Add(spacing); l.next.Before(next) {
+			l.next = next
+		}
+	}
+}
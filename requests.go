@@ -6,7 +6,7 @@ import (
 
 func (c *Client) Ping() (Ping, error) {
 	var p Ping
-	err := c.Do(fmt.Sprintf("%s/ping", baseURL), nil, &p)
+	err := c.do("ping", fmt.Sprintf("%s/ping", c.baseURL), nil, &p)
 	return p, err
 }
 
@@ -16,13 +16,20 @@ func (c *Client) Ping() (Ping, error) {
 
 func (c *Client) SimpleSupportedVsCurrencies() ([]string, error) {
 	var scs []string
-	err := c.Do(fmt.Sprintf("%s/simple/supported_vs_currencies", baseURL), nil, &scs)
+	err := c.do("simple/supported_vs_currencies", fmt.Sprintf("%s/simple/supported_vs_currencies", c.baseURL), nil, &scs)
 	return scs, err
 }
 
 func (c *Client) SimplePrice(p SimplePriceParams) (SimplePrices, error) {
 	sps := SimplePrices{vsCurrencies: p.VsCurrencies}
-	err := c.Do(fmt.Sprintf("%s/simple/price", baseURL), p, &sps)
+	err := c.do("simple/price", fmt.Sprintf("%s/simple/price", c.baseURL), p, &sps)
+	return sps, err
+}
+
+// SimpleTokenPrice prices are keyed by the lower cased contract address.
+func (c *Client) SimpleTokenPrice(p SimpleTokenPriceParams) (SimplePrices, error) {
+	sps := SimplePrices{vsCurrencies: p.VsCurrencies}
+	err := c.do("simple/token_price/{id}", fmt.Sprintf("%s/simple/token_price/%s", c.baseURL, p.Id), p, &sps)
 	return sps, err
 }
 
@@ -30,36 +37,56 @@ func (c *Client) SimplePrice(p SimplePriceParams) (SimplePrices, error) {
 // Coins
 //
 
-func (c Client) CoinsList(p CoinsParams) ([]Coin, error) {
+// CoinsList drops the empty element CoinGecko sometimes sends first.
+func (c *Client) CoinsList(p CoinsParams) ([]Coin, error) {
 	var cs []Coin
-	err := c.Do(fmt.Sprintf("%s/coins/list", baseURL), p, &cs)
-	if len(cs[0].Id) == 0 {
+	err := c.do("coins/list", fmt.Sprintf("%s/coins/list", c.baseURL), p, &cs)
+	if len(cs) > 0 && len(cs[0].Id) == 0 {
+		c.warn("gocko dropped empty first element", "endpoint", "coins/list", "coin", cs[0])
 		cs = cs[1:]
 	}
 	return cs, err
 }
 
 func (c *Client) CoinsMarkets(p CoinsMarketsParams) ([]Market, error) {
-	var ms []Market
-	err := c.Do(fmt.Sprintf("%s/coins/markets", baseURL), p, &ms)
+	var ms markets
+	err := c.do("coins/markets", fmt.Sprintf("%s/coins/markets", c.baseURL), p, &ms)
 	return ms, err
 }
 
 func (c *Client) CoinsID(p CoinsDataParams) (CoinData, error) {
 	var cd CoinData
-	err := c.Do(fmt.Sprintf("%s/coins/%s", baseURL, p.Id), p, &cd)
+	err := c.do("coins/{id}", fmt.Sprintf("%s/coins/%s", c.baseURL, p.Id), p, &cd)
 	return cd, err
 }
 
+func (c *Client) CoinsHistory(p CoinsHistoryParams) (CoinHistory, error) {
+	var ch CoinHistory
+	err := c.do("coins/{id}/history", fmt.Sprintf("%s/coins/%s/history", c.baseURL, p.Id), p, &ch)
+	return ch, err
+}
+
+func (c *Client) CoinsStatusUpdates(p CoinsStatusUpdatesParams) ([]StatusUpdate, error) {
+	var sus StatusUpdates
+	err := c.do("coins/{id}/status_updates", fmt.Sprintf("%s/coins/%s/status_updates", c.baseURL, p.Id), p, &sus)
+	return sus.StatusUpdates, err
+}
+
 func (c *Client) CoinsMarketCharts(p CoinsChartsParams) (Charts, error) {
 	var ccs Charts
-	err := c.Do(fmt.Sprintf("%s/coins/%s/market_chart", baseURL, p.Id), p, &ccs)
+	err := c.do("coins/{id}/market_chart", fmt.Sprintf("%s/coins/%s/market_chart", c.baseURL, p.Id), p, &ccs)
+	return ccs, err
+}
+
+func (c *Client) CoinsMarketChartsRange(p CoinsChartsRangeParams) (Charts, error) {
+	var ccs Charts
+	err := c.do("coins/{id}/market_chart/range", fmt.Sprintf("%s/coins/%s/market_chart/range", c.baseURL, p.Id), p, &ccs)
 	return ccs, err
 }
 
 func (c *Client) CoinsOHLC(p CoinsOHLCParams) (OHLC, error) {
 	var ohlc OHLC
-	err := c.Do(fmt.Sprintf("%s/coins/%s/ohlc", baseURL, p.Id), p, &ohlc)
+	err := c.do("coins/{id}/ohlc", fmt.Sprintf("%s/coins/%s/ohlc", c.baseURL, p.Id), p, &ohlc)
 	return ohlc, err
 }
 
@@ -69,12 +96,22 @@ func (c *Client) CoinsOHLC(p CoinsOHLCParams) (OHLC, error) {
 
 func (c *Client) ExchangesList() (ExchangeList, error) {
 	var el ExchangeList
-	err := c.Do(fmt.Sprintf("%s/exchanges/list", baseURL), nil, &el)
+	err := c.do("exchanges/list", fmt.Sprintf("%s/exchanges/list", c.baseURL), nil, &el)
 	return el, err
 }
 
 func (c *Client) Exchanges(p ExchangesParams) ([]Exchange, error) {
 	var es []Exchange
-	err := c.Do(fmt.Sprintf("%s/exchanges", baseURL), p, &es)
+	err := c.do("exchanges", fmt.Sprintf("%s/exchanges", c.baseURL), p, &es)
 	return es, err
 }
+
+//
+// Status Updates
+//
+
+func (c *Client) StatusUpdates(p StatusUpdatesParams) ([]StatusUpdate, error) {
+	var sus StatusUpdates
+	err := c.do("status_updates", fmt.Sprintf("%s/status_updates", c.baseURL), p, &sus)
+	return sus.StatusUpdates, err
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"strings"
 	"testing"
+	"time"
 )
 
 var client = NewClient()
@@ -50,7 +51,7 @@ func TestClient_CoinsMarkets(t *testing.T) {
 			VsCurrency:            "usd",
 			PerPage:               42,
 			Sparkline:             true,
-			PriceChangePercentage: "1h,24h,7d",
+			PriceChangePercentage: []PriceChangeWindow{Window1h, Window24h, Window7d},
 		})
 		require.NoError(t, err)
 		require.Equal(t, 42, len(ms))
@@ -86,9 +87,11 @@ func TestClient_CoinsCharts(t *testing.T) {
 			require.Equal(t, v[0], ccs.MarketCaps[i][0])
 			require.Equal(t, v[0], ccs.TotalVolumes[i][0])
 		}
+		_, err := ccs.Rows()
+		require.NoError(t, err)
 	}
 	t.Run("Minutely", func(t *testing.T) {
-		ccs, err := client.CoinsMarketCharts(CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: "1"})
+		ccs, err := client.CoinsMarketCharts(CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: Days1})
 		require.NoError(t, err)
 		require.Equal(t, 12*24+1, len(ccs.Prices))
 		require.Equal(t, 12*24+1, len(ccs.TotalVolumes))
@@ -96,7 +99,7 @@ func TestClient_CoinsCharts(t *testing.T) {
 		tsIntegrity(ccs)
 	})
 	t.Run("Hourly", func(t *testing.T) {
-		ccs, err := client.CoinsMarketCharts(CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: "7"})
+		ccs, err := client.CoinsMarketCharts(CoinsChartsParams{Id: "polkadot", VsCurrency: "usd", Days: Days7})
 		require.NoError(t, err)
 		require.Equal(t, 7*24+1, len(ccs.Prices))
 		require.Equal(t, 7*24+1, len(ccs.TotalVolumes))
@@ -113,6 +116,17 @@ func TestClient_CoinsCharts(t *testing.T) {
 	})
 }
 
+func TestClient_CoinsChartsRange(t *testing.T) {
+	to := time.Now()
+	ccs, err := client.CoinsMarketChartsRange(CoinsChartsRangeParams{
+		Id: "polkadot", VsCurrency: "usd", From: to.AddDate(0, 0, -7), To: to,
+	})
+	require.NoError(t, err)
+	require.LessOrEqual(t, 7*24-1, len(ccs.Prices))
+	_, err = ccs.Rows()
+	require.NoError(t, err)
+}
+
 func TestClient_CoinsData(t *testing.T) {
 	cd, err := client.CoinsID(CoinsDataParams{Id: "solana"})
 	require.NoError(t, err)
@@ -123,22 +137,31 @@ func TestClient_CoinsData(t *testing.T) {
 
 func TestClient_CoinsOHLC(t *testing.T) {
 	t.Run("Minutely", func(t *testing.T) {
-		ohlc, err := client.CoinsOHLC(CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: "1"})
+		ohlc, err := client.CoinsOHLC(CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: Days1})
 		require.NoError(t, err)
 		require.LessOrEqual(t, 48, len(ohlc))
 	})
 	t.Run("Hourly", func(t *testing.T) {
-		ohlc, err := client.CoinsOHLC(CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: "7"})
+		ohlc, err := client.CoinsOHLC(CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: Days7})
 		require.NoError(t, err)
 		require.LessOrEqual(t, 6*7, len(ohlc))
 	})
 	t.Run("Daily", func(t *testing.T) {
-		ohlc, err := client.CoinsOHLC(CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: "90"})
+		ohlc, err := client.CoinsOHLC(CoinsOHLCParams{Id: "polkadot", VsCurrency: "usd", Days: Days90})
 		require.NoError(t, err)
 		require.LessOrEqual(t, 90/4, len(ohlc))
 	})
 }
 
+func TestClient_CoinsStatusUpdates(t *testing.T) {
+	sus, err := client.CoinsStatusUpdates(CoinsStatusUpdatesParams{Id: "polkadot", PerPage: 5})
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(sus), 5)
+	for _, su := range sus {
+		require.Equal(t, "polkadot", su.Project.Id)
+	}
+}
+
 func TestClient_ExchangesList(t *testing.T) {
 	el, err := client.ExchangesList()
 	require.NoError(t, err)
@@ -154,3 +177,27 @@ func TestClient_Exchanges(t *testing.T) {
 	require.NotEmpty(t, es[0].Id)
 	require.NotEmpty(t, es[0].Name)
 }
+
+func TestClient_StatusUpdates(t *testing.T) {
+	sus, err := client.StatusUpdates(StatusUpdatesParams{Category: "general", ProjectType: "coin", PerPage: 10})
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(sus), 10)
+	for _, su := range sus {
+		require.Equal(t, "general", su.Category)
+	}
+}
+
+func TestOnchain_Networks(t *testing.T) {
+	ns, err := client.Onchain().Networks(OnchainPageParams{})
+	require.NoError(t, err)
+	require.NotEmpty(t, ns)
+	require.NotEmpty(t, ns[0].Id)
+	require.NotEmpty(t, ns[0].Attributes.Name)
+}
+
+func TestOnchain_TopPools(t *testing.T) {
+	ps, err := client.Onchain().TopPools(OnchainPoolsParams{Network: "eth", Include: []string{"base_token", "dex"}})
+	require.NoError(t, err)
+	require.NotEmpty(t, ps.Data)
+	require.NotEmpty(t, ps.Included.Tokens[ps.Data[0].BaseTokenId()].Attributes.Symbol)
+}
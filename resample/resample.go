@@ -0,0 +1,161 @@
+// Package resample turns gocko.Charts series into fixed interval bars.
+package resample
+
+import (
+	"errors"
+	"time"
+
+	"github.com/esenmx/gocko"
+)
+
+var InvalidIntervalError = errors.New("invalid interval")
+
+const day = 24 * time.Hour
+
+type Bar struct {
+	Time      time.Time // bar open, in Options.Location
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+	MarketCap float64
+	Filled    bool // no points fell into the bar, values are carried forward from the previous one
+}
+
+type VolumeMode int
+
+const (
+	// VolumeLast keeps the last value of the bar, CoinGecko's total_volumes is already a rolling 24h sum.
+	VolumeLast VolumeMode = iota
+	// VolumeSum adds up every value in the bar.
+	VolumeSum
+)
+
+type Options struct {
+	Interval time.Duration  // required, either a divisor of 24h (eg. 15m, 4h) or a multiple of it (eg. 24h, 7*24h)
+	Location *time.Location // bar boundaries are aligned to midnight of this location, defaults to UTC
+	Volume   VolumeMode
+	FillGaps bool // emit Filled bars for intervals without points
+}
+
+// Charts resamples the joined rows of c, which are expected in ascending order.
+// Intervals of whole days are counted in calendar days, so they follow DST changes of Location, and
+// multiples of 7 days start on Monday.
+func Charts(c gocko.Charts, opts Options) ([]Bar, error) {
+	rows, err := c.Rows()
+	if err != nil {
+		return nil, err
+	}
+	b, err := newBucketer(opts)
+	if err != nil {
+		return nil, err
+	}
+	var bars []Bar
+	for _, r := range rows {
+		start := b.start(r.Time)
+		if n := len(bars); n > 0 && bars[n-1].Time.Equal(start) {
+			bar := &bars[n-1]
+			if r.Price > bar.High {
+				bar.High = r.Price
+			}
+			if r.Price < bar.Low {
+				bar.Low = r.Price
+			}
+			bar.Close = r.Price
+			bar.MarketCap = r.MarketCap
+			if opts.Volume == VolumeSum {
+				bar.Volume += r.Volume
+			} else {
+				bar.Volume = r.Volume
+			}
+			continue
+		}
+		if n := len(bars); n > 0 && opts.FillGaps {
+			prev := bars[n-1]
+			for t := b.next(prev.Time); t.Before(start); t = b.next(t) {
+				bars = append(bars, fill(prev, t, opts.Volume))
+			}
+		}
+		bars = append(bars, Bar{
+			Time:      start,
+			Open:      r.Price,
+			High:      r.Price,
+			Low:       r.Price,
+			Close:     r.Price,
+			Volume:    r.Volume,
+			MarketCap: r.MarketCap,
+		})
+	}
+	return bars, nil
+}
+
+func fill(prev Bar, t time.Time, mode VolumeMode) Bar {
+	bar := Bar{
+		Time:      t,
+		Open:      prev.Close,
+		High:      prev.Close,
+		Low:       prev.Close,
+		Close:     prev.Close,
+		MarketCap: prev.MarketCap,
+		Filled:    true,
+	}
+	if mode == VolumeLast {
+		bar.Volume = prev.Volume
+	}
+	return bar
+}
+
+type bucketer struct {
+	interval time.Duration
+	days     int
+	loc      *time.Location
+}
+
+func newBucketer(opts Options) (bucketer, error) {
+	b := bucketer{interval: opts.Interval, loc: opts.Location}
+	if b.loc == nil {
+		b.loc = time.UTC
+	}
+	switch {
+	case b.interval <= 0:
+		return b, InvalidIntervalError
+	case b.interval < day:
+		if day%b.interval != 0 {
+			return b, InvalidIntervalError
+		}
+	default:
+		if b.interval%day != 0 {
+			return b, InvalidIntervalError
+		}
+		b.days = int(b.interval / day)
+	}
+	return b, nil
+}
+
+func (b bucketer) midnight(t time.Time) time.Time {
+	t = t.In(b.loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, b.loc)
+}
+
+func (b bucketer) start(t time.Time) time.Time {
+	m := b.midnight(t)
+	if b.days == 0 {
+		return m.Add(t.Sub(m) / b.interval * b.interval)
+	}
+	// count calendar days from a Monday so that weekly bars start on Mondays
+	epoch := time.Date(1970, 1, 5, 0, 0, 0, 0, b.loc)
+	n := int(m.Sub(epoch).Round(day) / day)
+	offset := n % b.days
+	if offset < 0 {
+		offset += b.days
+	}
+	return m.AddDate(0, 0, -offset)
+}
+
+func (b bucketer) next(start time.Time) time.Time {
+	if b.days == 0 {
+		return b.start(start.Add(b.interval))
+	}
+	return start.AddDate(0, 0, b.days)
+}
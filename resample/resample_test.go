@@ -0,0 +1,75 @@
+package resample
+
+import (
+	"github.com/esenmx/gocko"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func charts(start time.Time, step time.Duration, prices ...float64) gocko.Charts {
+	var c gocko.Charts
+	for i, p := range prices {
+		ts := float64(start.Add(time.Duration(i) * step).UnixMilli())
+		c.Prices = append(c.Prices, [2]float64{ts, p})
+		c.MarketCaps = append(c.MarketCaps, [2]float64{ts, p * 100})
+		c.TotalVolumes = append(c.TotalVolumes, [2]float64{ts, 1})
+	}
+	return c
+}
+
+func TestCharts(t *testing.T) {
+	start := time.Date(2022, 3, 29, 0, 5, 0, 0, time.UTC)
+	c := charts(start, 5*time.Minute, 10, 12, 9, 11, 13, 14)
+	bars, err := Charts(c, Options{Interval: 15 * time.Minute, Volume: VolumeSum})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(bars))
+	require.Equal(t, Bar{Time: start.Add(-5 * time.Minute), Open: 10, High: 12, Low: 10, Close: 12, Volume: 2, MarketCap: 1200}, bars[0])
+	require.Equal(t, Bar{Time: start.Add(10 * time.Minute), Open: 9, High: 13, Low: 9, Close: 13, Volume: 3, MarketCap: 1300}, bars[1])
+	require.Equal(t, 14.0, bars[2].Open)
+
+	bars, err = Charts(c, Options{Interval: time.Hour})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(bars))
+	require.Equal(t, 1.0, bars[0].Volume)
+
+	_, err = Charts(c, Options{Interval: 7 * time.Hour})
+	require.ErrorIs(t, err, InvalidIntervalError)
+	_, err = Charts(c, Options{Interval: 36 * time.Hour})
+	require.ErrorIs(t, err, InvalidIntervalError)
+	c.Prices = c.Prices[1:]
+	_, err = Charts(c, Options{Interval: time.Hour})
+	require.ErrorIs(t, err, gocko.MisalignedSeriesError)
+}
+
+func TestChartsFillGaps(t *testing.T) {
+	start := time.Date(2022, 3, 29, 0, 0, 0, 0, time.UTC)
+	c := charts(start, 2*time.Hour, 10, 20)
+	bars, err := Charts(c, Options{Interval: time.Hour, FillGaps: true})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(bars))
+	require.Equal(t, Bar{Time: start.Add(time.Hour), Open: 10, High: 10, Low: 10, Close: 10, Volume: 1, MarketCap: 1000, Filled: true}, bars[1])
+	bars, err = Charts(c, Options{Interval: time.Hour, FillGaps: true, Volume: VolumeSum})
+	require.NoError(t, err)
+	require.Equal(t, 0.0, bars[1].Volume)
+}
+
+func TestChartsLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// 2022-03-13 is 23 hours long in New York
+	start := time.Date(2022, 3, 12, 12, 0, 0, 0, ny)
+	c := charts(start, 12*time.Hour, 1, 2, 3, 4, 5, 6)
+	bars, err := Charts(c, Options{Interval: 24 * time.Hour, Location: ny})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(bars))
+	require.Equal(t, time.Date(2022, 3, 12, 0, 0, 0, 0, ny), bars[0].Time)
+	require.Equal(t, time.Date(2022, 3, 13, 0, 0, 0, 0, ny), bars[1].Time)
+	require.Equal(t, time.Date(2022, 3, 14, 0, 0, 0, 0, ny), bars[2].Time)
+
+	bars, err = Charts(c, Options{Interval: 7 * 24 * time.Hour, Location: ny})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(bars))
+	require.Equal(t, time.Date(2022, 3, 7, 0, 0, 0, 0, ny), bars[0].Time)
+	require.Equal(t, time.Monday, bars[1].Time.Weekday())
+}
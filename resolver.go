@@ -0,0 +1,240 @@
+package gocko
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var CoinNotFoundError = errors.New("coin not found")
+
+type AmbiguousCoinError struct {
+	Query      string
+	Candidates []Candidate
+}
+
+func (e *AmbiguousCoinError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		ids[i] = c.Id
+	}
+	return fmt.Sprintf("ambiguous coin %q: %s", e.Query, strings.Join(ids, ", "))
+}
+
+type MatchKind int
+
+const (
+	MatchId MatchKind = iota
+	MatchSymbol
+	MatchName
+	MatchContract
+)
+
+type Candidate struct {
+	Coin
+	Match         MatchKind
+	MarketCapRank int // 0 when unranked or rank data isn't loaded
+	MarketCap     float64
+}
+
+type ResolverOptions struct {
+	VsCurrency      string        // loads rank data from coins/markets when set
+	MarketsPages    int           // pages of 250 coins loaded from coins/markets, defaults to 4
+	RefreshInterval time.Duration // the index is rebuilt on lookup once older than this, 0 never refreshes
+	Strict          bool          // ResolveId reports every ambiguity instead of preferring the highest market cap
+}
+
+// Resolver maps the symbols, names and contract addresses users know to CoinGecko ids.
+// The index is built from coins/list on first use. A failed refresh keeps the previous index and is retried
+// after RefreshInterval.
+type Resolver struct {
+	c    *Client
+	opts ResolverOptions
+
+	refreshing sync.Mutex // held by the lookup refreshing the index
+
+	mu          sync.RWMutex
+	updatedAt   time.Time
+	attemptedAt time.Time              // of the latest refresh, successful or not
+	candidates  map[string][]Candidate // lower cased id, symbol or name
+	contracts   map[string][]Candidate // lower cased address
+}
+
+func NewResolver(c *Client, opts ResolverOptions) *Resolver {
+	if opts.MarketsPages <= 0 {
+		opts.MarketsPages = 4
+	}
+	return &Resolver{c: c, opts: opts}
+}
+
+// Refresh rebuilds the index, on failure the previous one is kept.
+func (r *Resolver) Refresh() error {
+	r.mu.Lock()
+	r.attemptedAt = time.Now()
+	r.mu.Unlock()
+	cs, err := r.c.CoinsList(CoinsParams{IncludePlatform: true})
+	if err != nil {
+		return err
+	}
+	ranks := map[string]Market{}
+	if len(r.opts.VsCurrency) > 0 {
+		for page := 1; page <= r.opts.MarketsPages; page++ {
+			ms, err := r.c.CoinsMarkets(CoinsMarketsParams{
+				VsCurrency: r.opts.VsCurrency,
+				Order:      OrderMarketCapDesc,
+				PerPage:    250,
+				Page:       page,
+			})
+			if err != nil {
+				return err
+			}
+			for _, m := range ms {
+				ranks[m.Id] = m
+			}
+			if len(ms) < 250 {
+				break
+			}
+		}
+	}
+	candidates := map[string][]Candidate{}
+	contracts := map[string][]Candidate{}
+	for _, coin := range cs {
+		c := Candidate{Coin: coin, MarketCapRank: ranks[coin.Id].MarketCapRank, MarketCap: ranks[coin.Id].MarketCap}
+		for _, k := range []struct {
+			key   string
+			match MatchKind
+		}{{coin.Id, MatchId}, {coin.Symbol, MatchSymbol}, {coin.Name, MatchName}} {
+			key := strings.ToLower(k.key)
+			if len(key) == 0 || contains(candidates[key], coin.Id) {
+				continue
+			}
+			c.Match = k.match
+			candidates[key] = append(candidates[key], c)
+		}
+		c.Match = MatchContract
+		for _, address := range coin.Platforms {
+			contracts[strings.ToLower(address)] = append(contracts[strings.ToLower(address)], c)
+		}
+	}
+	for _, cs := range candidates {
+		sortCandidates(cs)
+	}
+	for _, cs := range contracts {
+		sortCandidates(cs)
+	}
+	r.mu.Lock()
+	r.candidates, r.contracts, r.updatedAt = candidates, contracts, time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// index builds the index on first use and refreshes it once stale. A single lookup refreshes it while the
+// others keep reading the previous index, whose failed refresh is only retried after RefreshInterval.
+func (r *Resolver) index() error {
+	built, stale := r.stale()
+	if !stale {
+		return nil
+	}
+	if built {
+		if r.refreshing.TryLock() {
+			defer r.refreshing.Unlock()
+			if _, stale = r.stale(); stale {
+				_ = r.Refresh()
+			}
+		}
+		return nil
+	}
+	r.refreshing.Lock()
+	defer r.refreshing.Unlock()
+	if _, stale = r.stale(); !stale {
+		return nil
+	}
+	return r.Refresh()
+}
+
+func (r *Resolver) stale() (built, stale bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.updatedAt.IsZero() {
+		return false, true
+	}
+	return true, r.opts.RefreshInterval > 0 && time.Since(r.attemptedAt) > r.opts.RefreshInterval
+}
+
+// Resolve returns every coin whose id, symbol or name equals query case-insensitively, best candidate first:
+// ranked coins by market cap rank whatever they matched, then unranked coins with id matches before symbol
+// and name matches.
+func (r *Resolver) Resolve(query string) ([]Candidate, error) {
+	if err := r.index(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	cs := r.candidates[strings.ToLower(strings.TrimSpace(query))]
+	r.mu.RUnlock()
+	if len(cs) == 0 {
+		return nil, fmt.Errorf("%w: %q", CoinNotFoundError, query)
+	}
+	return append([]Candidate(nil), cs...), nil
+}
+
+// ResolveId picks the best candidate of Resolve, the highest market cap when rank data is loaded. An
+// *AmbiguousCoinError is returned when the best candidate is neither ranked nor an id match, or for any
+// ambiguity in strict mode.
+func (r *Resolver) ResolveId(query string) (string, error) {
+	cs, err := r.Resolve(query)
+	if err != nil {
+		return "", err
+	}
+	if len(cs) == 1 || (!r.opts.Strict && (cs[0].Match == MatchId || cs[0].MarketCapRank > 0)) {
+		return cs[0].Id, nil
+	}
+	return "", &AmbiguousCoinError{Query: query, Candidates: cs}
+}
+
+// ResolveContract finds the coins deployed at address, platform (eg. ethereum, binance-smart-chain) is optional.
+func (r *Resolver) ResolveContract(platform, address string) ([]Candidate, error) {
+	if err := r.index(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	all := r.contracts[strings.ToLower(strings.TrimSpace(address))]
+	r.mu.RUnlock()
+	var cs []Candidate
+	for _, c := range all {
+		if len(platform) == 0 || strings.EqualFold(c.Platforms[platform], address) {
+			cs = append(cs, c)
+		}
+	}
+	if len(cs) == 0 {
+		return nil, fmt.Errorf("%w: %s %s", CoinNotFoundError, platform, address)
+	}
+	return cs, nil
+}
+
+func contains(cs []Candidate, id string) bool {
+	for _, c := range cs {
+		if c.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func sortCandidates(cs []Candidate) {
+	sort.SliceStable(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if (a.MarketCapRank > 0) != (b.MarketCapRank > 0) {
+			return a.MarketCapRank > 0
+		}
+		if a.MarketCapRank != b.MarketCapRank {
+			return a.MarketCapRank < b.MarketCapRank
+		}
+		if a.Match != b.Match {
+			return a.Match < b.Match
+		}
+		return a.Id < b.Id
+	})
+}
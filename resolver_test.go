@@ -0,0 +1,110 @@
+package gocko
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResolver(t *testing.T) {
+	calls := map[string]int{}
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/api/v3/coins/list":
+			require.Equal(t, "true", r.URL.Query().Get("include_platform"))
+			return jsonResponse([]map[string]interface{}{
+				{"id": "ethereum", "symbol": "eth", "name": "Ethereum", "platforms": map[string]string{}},
+				{"id": "ethereum-wormhole", "symbol": "eth", "name": "Ethereum (Wormhole)", "platforms": map[string]string{
+					"solana": "7vfCXTUXx5WJV5JADk17DUJ4ksgau7utNKj4b963voxs"}},
+				{"id": "uniswap", "symbol": "uni", "name": "Uniswap", "platforms": map[string]string{
+					"ethereum": "0x1f9840a85d5af5b0fe9d5bf1d1d45c4eea3a6e22", "": ""}},
+				{"id": "unicorn-token", "symbol": "uni", "name": "Unicorn Token", "platforms": map[string]string{
+					"ethereum": "0x2730d6fdc86c95a74253beffaa8306b40fedecbb"}},
+				{"id": "eth", "symbol": "eth", "name": "Eth"},
+			})
+		case "/api/v3/coins/markets":
+			return jsonResponse([]map[string]interface{}{
+				{"id": "ethereum", "symbol": "eth", "name": "Ethereum", "market_cap": 213190216340, "market_cap_rank": 2},
+				{"id": "uniswap", "symbol": "uni", "name": "Uniswap", "market_cap": 5190216340, "market_cap_rank": 20},
+			})
+		}
+		return nil, fmt.Errorf("unexpected request %s", r.URL)
+	})}
+	c := NewClient(WithHttpClient(hc))
+
+	r := NewResolver(c, ResolverOptions{VsCurrency: "usd"})
+	cs, err := r.Resolve("UNI")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(cs))
+	require.Equal(t, "uniswap", cs[0].Id)
+	require.Equal(t, 20, cs[0].MarketCapRank)
+	require.Equal(t, MatchSymbol, cs[0].Match)
+	id, err := r.ResolveId("uni")
+	require.NoError(t, err)
+	require.Equal(t, "uniswap", id)
+
+	cs, err = r.Resolve("eth")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ethereum", "eth", "ethereum-wormhole"}, []string{cs[0].Id, cs[1].Id, cs[2].Id})
+	id, err = r.ResolveId("ETH")
+	require.NoError(t, err)
+	require.Equal(t, "ethereum", id)
+	id, err = r.ResolveId("Unicorn Token")
+	require.NoError(t, err)
+	require.Equal(t, "unicorn-token", id)
+
+	cs, err = r.ResolveContract("ethereum", "0x1F9840A85D5AF5B0FE9D5BF1D1D45C4EEA3A6E22")
+	require.NoError(t, err)
+	require.Equal(t, "uniswap", cs[0].Id)
+	require.Equal(t, MatchContract, cs[0].Match)
+	_, err = r.ResolveContract("solana", "0x1f9840a85d5af5b0fe9d5bf1d1d45c4eea3a6e22")
+	require.ErrorIs(t, err, CoinNotFoundError)
+	_, err = r.Resolve("doge")
+	require.ErrorIs(t, err, CoinNotFoundError)
+	require.Equal(t, 1, calls["/api/v3/coins/list"])
+
+	r = NewResolver(c, ResolverOptions{Strict: true})
+	_, err = r.ResolveId("uni")
+	var ae *AmbiguousCoinError
+	require.ErrorAs(t, err, &ae)
+	require.Equal(t, 2, len(ae.Candidates))
+	require.Equal(t, `ambiguous coin "uni": unicorn-token, uniswap`, ae.Error())
+	r = NewResolver(c, ResolverOptions{})
+	_, err = r.ResolveId("uni")
+	require.ErrorAs(t, err, &ae)
+	// without rank data the id match breaks the tie
+	id, err = r.ResolveId("ETH")
+	require.NoError(t, err)
+	require.Equal(t, "eth", id)
+	require.Equal(t, 1, calls["/api/v3/coins/markets"])
+
+	// a failed refresh keeps the index and isn't retried on every lookup
+	hc.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls[r.URL.Path]++
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Body: http.NoBody}, nil
+	})
+	r.opts.RefreshInterval = time.Hour
+	expire := func() {
+		r.mu.Lock()
+		r.attemptedAt = r.attemptedAt.Add(-2 * time.Hour)
+		r.mu.Unlock()
+	}
+	listed := calls["/api/v3/coins/list"]
+	expire()
+	for i := 0; i < 3; i++ {
+		id, err = r.ResolveId("ETH")
+		require.NoError(t, err)
+		require.Equal(t, "eth", id)
+	}
+	require.Equal(t, listed+1, calls["/api/v3/coins/list"])
+	// lookups don't wait for another one's refresh
+	expire()
+	r.refreshing.Lock()
+	_, err = r.Resolve("eth")
+	r.refreshing.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, listed+1, calls["/api/v3/coins/list"])
+}
@@ -0,0 +1,169 @@
+// Package telemetry instruments a gocko.Client with spans and metrics through a middleware.
+//
+// It doesn't import OpenTelemetry so gocko stays dependency-free, Tracer and Metrics mirror its API
+// closely enough to be implemented with a few lines on top of otel/trace and otel/metric:
+//
+//	type otelTracer struct{ t trace.Tracer }
+//
+//	func (o otelTracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+//		ctx, span := o.t.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(toKeyValues(attrs)...))
+//		return ctx, otelSpan{span}
+//	}
+//
+// The client doesn't retry failed calls, so spans carry no retry count, each call is a single attempt.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/esenmx/gocko"
+)
+
+// Attribute keys, the status code follows the OpenTelemetry HTTP semantic conventions.
+const (
+	EndpointKey      = "gocko.endpoint"
+	CoinIdKey        = "gocko.coin_id"
+	CoinCountKey     = "gocko.coin_count" // instead of CoinIdKey when a call asks for several coins
+	VsCurrencyKey    = "gocko.vs_currency"
+	RateLimitWaitKey = "gocko.rate_limit_wait_ms"
+	StatusCodeKey    = "http.response.status_code"
+	ErrorTypeKey     = "error.type"
+)
+
+// Error types reported under ErrorTypeKey.
+const (
+	ErrorStatus    = "status"    // non 2xx response, see gocko.StatusError
+	ErrorDecode    = "decode"    // unexpected payload, see gocko.DecodeError
+	ErrorTransport = "transport" // no response
+)
+
+type Attribute struct {
+	Key   string
+	Value interface{} // string, int or int64
+}
+
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Metrics receive the endpoint attribute along every measurement, the error type along errors.
+type Metrics interface {
+	RecordLatency(ctx context.Context, d time.Duration, attrs ...Attribute)
+	RecordRateLimitWait(ctx context.Context, d time.Duration, attrs ...Attribute)
+	AddError(ctx context.Context, attrs ...Attribute)
+}
+
+// Options may leave Tracer or Metrics nil to only trace or measure.
+type Options struct {
+	Tracer  Tracer
+	Metrics Metrics
+}
+
+// Middleware traces each call as a span named "gocko <endpoint>", the span context is set on the request
+// so transports instrumented further down, eg. by otelhttp, nest their spans under it.
+//
+//	c := gocko.NewClient(gocko.WithMiddleware(telemetry.Middleware(telemetry.Options{Tracer: t, Metrics: m})))
+func Middleware(opts Options) gocko.Middleware {
+	return func(next gocko.DoFunc) gocko.DoFunc {
+		return func(call *gocko.Call) error {
+			ctx := call.Request.Context()
+			attrs := callAttributes(call)
+			var span Span
+			if opts.Tracer != nil {
+				ctx, span = opts.Tracer.Start(ctx, "gocko "+call.Endpoint, attrs...)
+				call.Request = call.Request.WithContext(ctx)
+			}
+			start := time.Now()
+			err := next(call)
+			latency := time.Since(start)
+
+			endpoint := Attribute{EndpointKey, call.Endpoint}
+			if span != nil {
+				if call.StatusCode != 0 {
+					span.SetAttributes(Attribute{StatusCodeKey, call.StatusCode})
+				}
+				if call.RateLimitWait > 0 {
+					span.SetAttributes(Attribute{RateLimitWaitKey, call.RateLimitWait.Milliseconds()})
+				}
+				if err != nil {
+					span.SetAttributes(Attribute{ErrorTypeKey, ErrorType(err)})
+					span.RecordError(err)
+				}
+				span.End()
+			}
+			if opts.Metrics != nil {
+				opts.Metrics.RecordLatency(ctx, latency, endpoint)
+				if call.RateLimitWait > 0 {
+					opts.Metrics.RecordRateLimitWait(ctx, call.RateLimitWait, endpoint)
+				}
+				if err != nil {
+					opts.Metrics.AddError(ctx, endpoint, Attribute{ErrorTypeKey, ErrorType(err)})
+				}
+			}
+			return err
+		}
+	}
+}
+
+// ErrorType classifies err as ErrorStatus, ErrorDecode or ErrorTransport.
+func ErrorType(err error) string {
+	var se *gocko.StatusError
+	var de *gocko.DecodeError
+	switch {
+	case errors.As(err, &se):
+		return ErrorStatus
+	case errors.As(err, &de):
+		return ErrorDecode
+	default:
+		return ErrorTransport
+	}
+}
+
+// callAttributes reads the coin id from the {id} segment of the path or the ids parameter, whose batches can
+// list thousands of coins and are only counted, and the vs currency from the vs_currency or vs_currencies parameter.
+func callAttributes(call *gocko.Call) []Attribute {
+	attrs := []Attribute{{EndpointKey, call.Endpoint}}
+	if id := pathId(call.Endpoint, call.Request); len(id) > 0 {
+		attrs = append(attrs, Attribute{CoinIdKey, id})
+	} else if ids := call.Request.URL.Query().Get("ids"); len(ids) > 0 {
+		if n := strings.Count(ids, ",") + 1; n > 1 {
+			attrs = append(attrs, Attribute{CoinCountKey, n})
+		} else {
+			attrs = append(attrs, Attribute{CoinIdKey, ids})
+		}
+	}
+	q := call.Request.URL.Query()
+	for _, key := range []string{"vs_currency", "vs_currencies"} {
+		if vsc := q.Get(key); len(vsc) > 0 {
+			attrs = append(attrs, Attribute{VsCurrencyKey, vsc})
+			break
+		}
+	}
+	return attrs
+}
+
+// pathId matches the endpoint template against the trailing segments of the request path.
+func pathId(endpoint string, req *http.Request) string {
+	tpl := strings.Split(endpoint, "/")
+	segs := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segs) < len(tpl) {
+		return ""
+	}
+	segs = segs[len(segs)-len(tpl):]
+	for i, s := range tpl {
+		if s == "{id}" {
+			return segs[i]
+		}
+	}
+	return ""
+}
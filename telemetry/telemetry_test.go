@@ -0,0 +1,106 @@
+package telemetry
+
+import (
+	"context"
+	"github.com/esenmx/gocko"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+type spanKey struct{}
+
+type span struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *span) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+func (s *span) RecordError(err error) { s.err = err }
+func (s *span) End()                  { s.ended = true }
+
+type tracer struct{ spans []*span }
+
+func (t *tracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	s := &span{name: name, attrs: map[string]interface{}{}}
+	s.SetAttributes(attrs...)
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+type metrics struct {
+	latencies []string
+	waits     int
+	errors    []string
+}
+
+func (m *metrics) RecordLatency(_ context.Context, _ time.Duration, attrs ...Attribute) {
+	m.latencies = append(m.latencies, attrs[0].Value.(string))
+}
+func (m *metrics) RecordRateLimitWait(context.Context, time.Duration, ...Attribute) { m.waits++ }
+func (m *metrics) AddError(_ context.Context, attrs ...Attribute) {
+	m.errors = append(m.errors, attrs[1].Value.(string))
+}
+
+func TestMiddleware(t *testing.T) {
+	tr, m := &tracer{}, &metrics{}
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.NotNil(t, r.Context().Value(spanKey{}))
+		switch r.URL.Path {
+		case "/api/v3/coins/bitcoin/ohlc":
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[[1626912000000,1,2,0.5,1.5]]`))}, nil
+		case "/api/v3/simple/price":
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[]`))}, nil
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		}
+	})}
+	c := gocko.NewClient(gocko.WithHttpClient(hc), gocko.WithMiddleware(Middleware(Options{Tracer: tr, Metrics: m})))
+
+	_, err := c.CoinsOHLC(gocko.CoinsOHLCParams{Id: "bitcoin", VsCurrency: "usd", Days: gocko.Days1})
+	require.NoError(t, err)
+	_, err = c.SimplePrice(gocko.SimplePriceParams{Ids: []string{"bitcoin", "ethereum"}, VsCurrencies: []string{"usd"}})
+	require.Error(t, err)
+	_, err = c.CoinsID(gocko.CoinsDataParams{Id: "unknown"})
+	require.Error(t, err)
+
+	require.Len(t, tr.spans, 3)
+	s := tr.spans[0]
+	require.Equal(t, "gocko coins/{id}/ohlc", s.name)
+	require.True(t, s.ended)
+	require.Equal(t, map[string]interface{}{
+		EndpointKey:   "coins/{id}/ohlc",
+		CoinIdKey:     "bitcoin",
+		VsCurrencyKey: "usd",
+		StatusCodeKey: 200,
+	}, s.attrs)
+	require.NoError(t, s.err)
+
+	s = tr.spans[1]
+	require.Equal(t, 2, s.attrs[CoinCountKey])
+	require.NotContains(t, s.attrs, CoinIdKey)
+	require.Equal(t, ErrorDecode, s.attrs[ErrorTypeKey])
+	require.Error(t, s.err)
+
+	s = tr.spans[2]
+	require.Equal(t, "unknown", s.attrs[CoinIdKey])
+	require.Equal(t, 404, s.attrs[StatusCodeKey])
+	require.Equal(t, ErrorStatus, s.attrs[ErrorTypeKey])
+
+	require.Equal(t, []string{"coins/{id}/ohlc", "simple/price", "coins/{id}"}, m.latencies)
+	require.Equal(t, []string{ErrorDecode, ErrorStatus}, m.errors)
+	require.Zero(t, m.waits)
+}
@@ -0,0 +1,209 @@
+package gocko
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type PriceUpdate struct {
+	Id            string
+	VsCurrency    string
+	Price         Price
+	LastUpdatedAt time.Time
+}
+
+type WatcherOptions struct {
+	Interval          time.Duration // defaults to 1 minute, CoinGecko refreshes prices about every 60 seconds
+	MaxBackoff        time.Duration // the interval doubles on each consecutive error up to this, defaults to 10 minutes
+	IncludeMarketCap  bool
+	Include24hrVol    bool
+	Include24hrChange bool
+	Buffer            int             // capacity of the Updates channel
+	OnError           func(err error) // called from Run for each failed poll
+}
+
+// Watcher polls SimplePrice for the subscribed coins and emits the prices that changed since the previous poll.
+type Watcher struct {
+	c       *Client
+	opts    WatcherOptions
+	updates chan PriceUpdate
+	wake    chan struct{}
+
+	mu   sync.Mutex
+	subs map[string]map[string]bool // id -> vs currency
+	last map[string]PriceUpdate     // id + "/" + vs currency
+}
+
+func NewWatcher(c *Client, opts WatcherOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 10 * time.Minute
+	}
+	return &Watcher{
+		c:       c,
+		opts:    opts,
+		updates: make(chan PriceUpdate, opts.Buffer),
+		wake:    make(chan struct{}, 1),
+		subs:    map[string]map[string]bool{},
+		last:    map[string]PriceUpdate{},
+	}
+}
+
+// Updates is closed once Run returns.
+func (w *Watcher) Updates() <-chan PriceUpdate { return w.updates }
+
+// Subscribe watches every combination of ids and vsCurrencies, their current prices are emitted on the next poll,
+// which is triggered right away.
+func (w *Watcher) Subscribe(ids []string, vsCurrencies []string) {
+	w.mu.Lock()
+	for _, id := range ids {
+		if w.subs[id] == nil {
+			w.subs[id] = map[string]bool{}
+		}
+		for _, vsc := range vsCurrencies {
+			w.subs[id][vsc] = true
+		}
+	}
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Unsubscribe stops watching the combinations of ids and vsCurrencies, all currencies of ids when vsCurrencies is nil.
+func (w *Watcher) Unsubscribe(ids []string, vsCurrencies []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range ids {
+		vscs := vsCurrencies
+		if vscs == nil {
+			for vsc := range w.subs[id] {
+				vscs = append(vscs, vsc)
+			}
+		}
+		for _, vsc := range vscs {
+			delete(w.subs[id], vsc)
+			delete(w.last, id+"/"+vsc)
+		}
+		if len(w.subs[id]) == 0 {
+			delete(w.subs, id)
+		}
+	}
+}
+
+// Run polls until ctx is done. A request in flight isn't interrupted, Run returns once it completes.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.updates)
+	failures := 0
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		case <-w.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+		delay := w.opts.Interval
+		if err := w.poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+			failures++
+			for i := 0; i < failures && delay < w.opts.MaxBackoff; i++ {
+				delay *= 2
+			}
+			if delay > w.opts.MaxBackoff {
+				delay = w.opts.MaxBackoff
+			}
+		} else {
+			failures = 0
+		}
+		timer.Reset(delay)
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context) error {
+	w.mu.Lock()
+	var ids []string
+	vscSet := map[string]bool{}
+	for id, vscs := range w.subs {
+		ids = append(ids, id)
+		for vsc := range vscs {
+			vscSet[vsc] = true
+		}
+	}
+	w.mu.Unlock()
+	if len(ids) == 0 {
+		return nil
+	}
+	var vscs []string
+	for vsc := range vscSet {
+		vscs = append(vscs, vsc)
+	}
+	sps, err := w.c.SimplePriceBatch(SimplePriceBatchParams{SimplePriceParams: SimplePriceParams{
+		Ids:                  ids,
+		VsCurrencies:         vscs,
+		IncludeMarketCap:     w.opts.IncludeMarketCap,
+		Include24hrVol:       w.opts.Include24hrVol,
+		Include24hrChange:    w.opts.Include24hrChange,
+		IncludeLastUpdatedAt: true,
+	}})
+	// chunks that succeeded are still emitted
+	for _, u := range w.changes(sps) {
+		select {
+		case w.updates <- u:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// changes diffs sps against the previous snapshot and records it, coins whose LastUpdatedAt didn't move are skipped.
+func (w *Watcher) changes(sps SimplePrices) []PriceUpdate {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var us []PriceUpdate
+	for id, sp := range sps.Prices {
+		var updatedAt time.Time
+		if sp.LastUpdatedAt != nil {
+			updatedAt = time.Unix(*sp.LastUpdatedAt, 0).UTC()
+		}
+		for vsc := range w.subs[id] {
+			price, ok := sp.CurrencyPrice[vsc]
+			if !ok {
+				continue
+			}
+			key := id + "/" + vsc
+			u := PriceUpdate{Id: id, VsCurrency: vsc, Price: price, LastUpdatedAt: updatedAt}
+			prev, seen := w.last[key]
+			if seen && prev.LastUpdatedAt.Equal(updatedAt) {
+				continue
+			}
+			w.last[key] = u
+			if !seen || !samePrice(prev.Price, price) {
+				us = append(us, u)
+			}
+		}
+	}
+	return us
+}
+
+func samePrice(a, b Price) bool {
+	eq := func(a, b *float64) bool { return (a == nil && b == nil) || (a != nil && b != nil && *a == *b) }
+	return a.Price == b.Price && eq(a.MarketCap, b.MarketCap) && eq(a.Vol24h, b.Vol24h) && eq(a.Change24h, b.Change24h)
+}
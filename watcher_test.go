@@ -0,0 +1,91 @@
+package gocko
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatcher(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	prices := map[string]map[string]float64{
+		"polkadot": {"usd": 12.2, "eur": 11.1, "last_updated_at": 1},
+		"solana":   {"usd": 25.6, "eur": 23.4, "last_updated_at": 1},
+	}
+	hc := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		if polls == 3 {
+			return nil, errors.New("boom")
+		}
+		res := map[string]map[string]float64{}
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			res[id] = map[string]float64{}
+			for _, vsc := range append(strings.Split(r.URL.Query().Get("vs_currencies"), ","), "last_updated_at") {
+				res[id][vsc] = prices[id][vsc]
+			}
+		}
+		return jsonResponse(res)
+	})}
+	set := func(id, vsc string, price float64) {
+		mu.Lock()
+		prices[id][vsc] = price
+		prices[id]["last_updated_at"]++
+		mu.Unlock()
+	}
+	var errs []error
+	w := NewWatcher(NewClient(WithHttpClient(hc)), WatcherOptions{
+		Interval:   5 * time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+		OnError:    func(err error) { errs = append(errs, err) },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+	next := func() PriceUpdate {
+		select {
+		case u := <-w.Updates():
+			return u
+		case <-time.After(time.Second):
+			t.Fatal("no update")
+		}
+		return PriceUpdate{}
+	}
+
+	w.Subscribe([]string{"polkadot"}, []string{"usd"})
+	u := next()
+	require.Equal(t, "polkadot", u.Id)
+	require.Equal(t, "usd", u.VsCurrency)
+	require.Equal(t, 12.2, u.Price.Price)
+	require.Equal(t, time.Unix(1, 0).UTC(), u.LastUpdatedAt)
+
+	set("polkadot", "eur", 11.2) // not subscribed
+	set("polkadot", "usd", 12.3)
+	u = next()
+	require.Equal(t, 12.3, u.Price.Price)
+
+	w.Subscribe([]string{"solana"}, []string{"eur"})
+	u = next()
+	require.Equal(t, "solana", u.Id)
+	require.Equal(t, 23.4, u.Price.Price)
+
+	w.Unsubscribe([]string{"polkadot"}, nil)
+	set("polkadot", "usd", 12.4)
+	set("solana", "eur", 23.5)
+	u = next()
+	require.Equal(t, "solana", u.Id)
+	require.Equal(t, 23.5, u.Price.Price)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	_, open := <-w.Updates()
+	require.False(t, open)
+	require.NotEmpty(t, errs)
+}